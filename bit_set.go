@@ -0,0 +1,58 @@
+package sudoku
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// BitSet is a compact set of the integers 1 to 64
+type BitSet uint64
+
+// fullBitSet returns a BitSet containing the integers 1 to n
+func fullBitSet(n int) BitSet {
+	if n >= 64 {
+		return ^BitSet(0)
+	}
+	return BitSet(1)<<uint(n) - 1
+}
+
+// Add an entry
+func (set *BitSet) Add(i int) bool {
+	bit := BitSet(1) << uint(i-1)
+	found := *set&bit != 0
+	*set |= bit
+	return !found // false if it existed already
+}
+
+// Remove an entry
+func (set *BitSet) Remove(i int) bool {
+	bit := BitSet(1) << uint(i-1)
+	found := *set&bit != 0
+	*set &^= bit
+	return found // false if it didn't exist
+}
+
+// Contains tells if the integer is already in the set
+func (set BitSet) Contains(i int) bool {
+	return set&(BitSet(1)<<uint(i-1)) != 0
+}
+
+// Len returns the number of entries
+func (set BitSet) Len() int {
+	return bits.OnesCount64(uint64(set))
+}
+
+// Values returns all values in the set (sorted)
+func (set BitSet) Values() []int {
+	result := make([]int, 0, set.Len())
+	for set != 0 {
+		result = append(result, bits.TrailingZeros64(uint64(set))+1)
+		set &= set - 1
+	}
+	return result
+}
+
+// String returns a string representation
+func (set BitSet) String() string {
+	return fmt.Sprintf("%v", set.Values())
+}
@@ -0,0 +1,41 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSetAdd(t *testing.T) {
+	var s BitSet
+	assert.Equal(t, 0, s.Len())
+	assert.True(t, s.Add(3))
+	assert.False(t, s.Add(3))
+	assert.True(t, s.Add(64))
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Contains(3))
+	assert.True(t, s.Contains(64))
+	assert.False(t, s.Contains(1))
+}
+
+func TestBitSetRemove(t *testing.T) {
+	var s BitSet
+	s.Add(1)
+	assert.True(t, s.Remove(1))
+	assert.False(t, s.Remove(1))
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestBitSetValues(t *testing.T) {
+	var s BitSet
+	for _, v := range []int{25, 1, 9, 3} {
+		s.Add(v)
+	}
+	assert.Equal(t, []int{1, 3, 9, 25}, s.Values())
+	assert.Equal(t, "[1 3 9 25]", s.String())
+}
+
+func TestFullBitSet(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 4}, fullBitSet(4).Values())
+	assert.Equal(t, 64, fullBitSet(64).Len())
+}
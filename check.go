@@ -0,0 +1,112 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotUnique is returned if a sudoku has more than one solution
+var ErrNotUnique = errors.New("sudoku has more than one solution")
+
+// GivenError is returned if a given of the puzzle was changed in a grid
+// checked against it
+type GivenError struct {
+	Row, Col int
+	Given    int
+	// Value is the value found in the grid, 0 if the field was cleared
+	Value int
+}
+
+func (e *GivenError) Error() string {
+	return fmt.Sprintf("given %d at row %d, col %d was changed to %d", e.Given, e.Row, e.Col, e.Value)
+}
+
+// CheckResult reports the mistakes in a grid filled in by a player
+type CheckResult struct {
+	// Incorrect are the indices of the filled fields that don't match the
+	// solution
+	Incorrect []int
+	// Conflicts are the indices of the fields holding the same value as a
+	// related field
+	Conflicts []int
+	// Solvable is true if the grid can still be completed to the solution
+	Solvable bool
+	// Solution is the unique solution of the puzzle
+	Solution *Sudoku
+}
+
+// Check compares a grid filled in by a player with the puzzle it was started
+// from. The givens of the puzzle must be unchanged and the puzzle must have a
+// unique solution. Values of the puzzle that aren't givens, e.g. deduced ones
+// saved as JSON, may be changed by the player.
+func Check(puzzle, grid *Sudoku) (CheckResult, error) {
+	if puzzle.Size != grid.Size {
+		return CheckResult{}, fmt.Errorf("grid of size %d doesn't match puzzle of size %d", grid.Size, puzzle.Size)
+	}
+	givens := make([]int, len(puzzle.Fields))
+	for i, f := range puzzle.Fields {
+		if !f.IsGiven() {
+			continue
+		}
+		givens[i] = f.Value
+		if grid.Fields[i].Value != f.Value {
+			return CheckResult{}, &GivenError{
+				Row:   i / puzzle.MaxValue,
+				Col:   i % puzzle.MaxValue,
+				Given: f.Value,
+				Value: grid.Fields[i].Value,
+			}
+		}
+	}
+
+	solution := New(puzzle.Size)
+	solution.Init(givens)
+	if err := solution.Validate(); err != nil {
+		return CheckResult{}, err
+	}
+	if count := solution.CountSolutions(2); count == 0 {
+		return CheckResult{}, ErrNoSolution
+	} else if count > 1 {
+		return CheckResult{}, ErrNotUnique
+	}
+	solution.SolveDancingLinks(SolveOptions{})
+
+	result := CheckResult{
+		Incorrect: make([]int, 0),
+		Conflicts: grid.Conflicts(),
+		Solution:  solution,
+	}
+	for i, f := range grid.Fields {
+		if f.IsSolved() && f.Value != solution.Fields[i].Value {
+			result.Incorrect = append(result.Incorrect, i)
+		}
+	}
+	// as the solution is unique, every correct grid can be completed to it
+	result.Solvable = len(result.Incorrect) == 0
+	return result, nil
+}
+
+// Conflicts returns the indices of the fields whose value can't be put there
+// because a related field in the same row, col or block holds it too
+func (s Sudoku) Conflicts() []int {
+	result := make([]int, 0)
+	for _, f := range s.Fields {
+		if f.IsSolved() && s.hasPeerWithValue(f) {
+			result = append(result, f.Index)
+		}
+	}
+	return result
+}
+
+// hasPeerWithValue checks like CanPut if another field related to f holds
+// the value of f
+func (s Sudoku) hasPeerWithValue(f *Field) bool {
+	for _, group := range []FieldGroup{s.GetRow(f), s.GetCol(f), s.GetBlock(f)} {
+		for _, peer := range group.Fields {
+			if peer != f && peer.Value == f.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package sudoku
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	puzzle, _ := FromFile("testfiles/easy.sudoku")
+	grid := puzzle.Clone()
+
+	result, err := Check(puzzle, grid)
+	assert.Nil(t, err)
+	assert.True(t, result.Solvable)
+	assert.Empty(t, result.Incorrect)
+	assert.Empty(t, result.Conflicts)
+	assert.True(t, result.Solution.IsValidSolution())
+
+	grid.Fields[2].Value = result.Solution.Fields[2].Value
+	grid.Fields[3*9+1].Value = 3 - result.Solution.Fields[3*9+1].Value%2
+	result, err = Check(puzzle, grid)
+	assert.Nil(t, err)
+	assert.False(t, result.Solvable)
+	assert.Equal(t, []int{3*9 + 1}, result.Incorrect)
+
+	// 9 is given in the same row
+	grid.Fields[3*9+1].Value = 0
+	grid.Fields[2].Value = 9
+	result, err = Check(puzzle, grid)
+	assert.Nil(t, err)
+	assert.False(t, result.Solvable)
+	assert.Equal(t, []int{2}, result.Incorrect)
+	assert.Equal(t, []int{0, 2}, result.Conflicts)
+}
+
+func TestCheckErrors(t *testing.T) {
+	puzzle, _ := FromFile("testfiles/easy.sudoku")
+	grid := puzzle.Clone()
+	grid.Fields[1].Value = 0
+	_, err := Check(puzzle, grid)
+	assert.Equal(t, &GivenError{Row: 0, Col: 1, Given: 6, Value: 0}, err)
+
+	_, err = Check(puzzle, New(2))
+	assert.NotNil(t, err)
+
+	empty := New(2)
+	_, err = Check(empty, empty.Clone())
+	assert.Equal(t, ErrNotUnique, err)
+}
+
+func TestCheckDeducedValues(t *testing.T) {
+	easy, _ := FromFile("testfiles/easy.sudoku")
+	easy.Reason()
+	easy.SolveStep(SolveOptions{})
+	step := easy.SolveStep(SolveOptions{}).Step
+	data, _ := json.Marshal(easy)
+	var puzzle Sudoku
+	assert.Nil(t, json.Unmarshal(data, &puzzle))
+	deduced := step.Fields[0]
+	assert.True(t, puzzle.Fields[deduced].IsSolved())
+	assert.False(t, puzzle.Fields[deduced].IsGiven())
+
+	// the player hasn't got that far or entered another value
+	grid := puzzle.Clone()
+	grid.Fields[deduced].Value = 0
+	result, err := Check(&puzzle, grid)
+	assert.Nil(t, err)
+	assert.True(t, result.Solvable)
+
+	grid.Fields[deduced].Value = step.Value%9 + 1
+	result, err = Check(&puzzle, grid)
+	assert.Nil(t, err)
+	assert.Contains(t, result.Incorrect, deduced)
+}
+
+func TestConflicts(t *testing.T) {
+	s := New(2).Init([]int{
+		1, 2, 0, 1,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		2, 0, 0, 0,
+	})
+	assert.Equal(t, []int{0, 3}, s.Conflicts())
+	assert.Equal(t, 1, s.Fields[0].Value)
+}
+
+func TestCheckDuplicates(t *testing.T) {
+	puzzle, _ := FromFile("testfiles/easy.sudoku")
+	data, _ := ioutil.ReadFile("testfiles/easy.sudoku")
+	// 9 twice in row 0 and a wrong 1 in row 1
+	grid, err := ReadUnchecked(strings.NewReader(strings.Replace(strings.Replace(string(data), "96.", "969", 1), "...4", "1..4", 1)), FormatAuto, Notation{})
+	assert.Nil(t, err)
+	_, err = Read(strings.NewReader(strings.Replace(string(data), "96.", "969", 1)), FormatAuto, Notation{})
+	assert.IsType(t, &DuplicateError{}, err)
+
+	result, err := Check(puzzle, grid)
+	assert.Nil(t, err)
+	assert.False(t, result.Solvable)
+	assert.Equal(t, []int{2, 9}, result.Incorrect)
+	assert.Equal(t, []int{0, 2}, result.Conflicts)
+}
@@ -0,0 +1,152 @@
+package sudoku
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SolveSimpleColoring colors the chains of conjugate pairs of a value (groups
+// in which the value is possible in exactly two fields) alternately. If two
+// fields of the same color see each other, that color can't hold the value.
+// Fields seeing both colors can't hold the value either (one step!)
+func (s Sudoku) SolveSimpleColoring() SolvingResult {
+	for val := 1; val <= s.MaxValue; val++ {
+		links := make(map[*Field][]*Field)
+		for _, group := range s.groups() {
+			fields := group.possibleFields(val)
+			if len(fields) != 2 {
+				continue
+			}
+			links[fields[0]] = append(links[fields[0]], fields[1])
+			links[fields[1]] = append(links[fields[1]], fields[0])
+		}
+
+		colors := make(map[*Field]int)
+		for _, start := range s.Fields {
+			if _, ok := links[start]; !ok {
+				continue
+			}
+			if _, colored := colors[start]; colored {
+				continue
+			}
+			chain := colorChain(start, links, colors)
+			if len(chain[0]) < 2 && len(chain[1]) < 2 {
+				continue
+			}
+			res := s.solveColorChain(val, chain)
+			if res.FoundNew {
+				return res
+			}
+		}
+	}
+	return SolvingResult{}
+}
+
+// colorChain colors all fields connected to start alternately with color
+// 0 and 1 and returns the fields per color
+func colorChain(start *Field, links map[*Field][]*Field, colors map[*Field]int) [2][]*Field {
+	var chain [2][]*Field
+	colors[start] = 0
+	queue := []*Field{start}
+	for len(queue) > 0 {
+		field := queue[0]
+		queue = queue[1:]
+		color := colors[field]
+		chain[color] = append(chain[color], field)
+		for _, linked := range links[field] {
+			if _, colored := colors[linked]; colored {
+				continue
+			}
+			colors[linked] = 1 - color
+			queue = append(queue, linked)
+		}
+	}
+	return chain
+}
+
+func (s Sudoku) solveColorChain(val int, chain [2][]*Field) SolvingResult {
+	fields := make([]int, 0, len(chain[0])+len(chain[1]))
+	for _, color := range chain {
+		for _, field := range color {
+			fields = append(fields, field.Index)
+		}
+	}
+	sort.Ints(fields)
+
+	// color wrap: one color contradicts itself
+	for color, colorFields := range chain {
+		if !anySeesEachOther(s, colorFields) {
+			continue
+		}
+		eliminations := make([]Elimination, 0, len(colorFields))
+		for _, field := range colorFields {
+			field.DenyValue(val)
+			eliminations = append(eliminations, Elimination{Field: field.Index, Value: val})
+		}
+		return SolvingResult{
+			FoundNew: true,
+			Step: Step{
+				Technique:    SimpleColoring,
+				Fields:       fields,
+				Eliminations: eliminations,
+				Message: fmt.Sprintf("Found %s on value %d: Fields %s of color %d see each other, so it is denied for all fields of that color",
+					SimpleColoring, val, formatInts(fieldIndices(colorFields)), color+1),
+			},
+		}
+	}
+
+	// color trap: fields outside the chain seeing both colors
+	eliminations := make([]Elimination, 0)
+	for _, field := range s.Fields {
+		if field.IsSolved() || field.NonValues.Contains(val) || containsInt(fields, field.Index) {
+			continue
+		}
+		if seesAny(s, field, chain[0]) && seesAny(s, field, chain[1]) {
+			field.DenyValue(val)
+			eliminations = append(eliminations, Elimination{Field: field.Index, Value: val})
+		}
+	}
+	if len(eliminations) == 0 {
+		return SolvingResult{}
+	}
+	return SolvingResult{
+		FoundNew: true,
+		Step: Step{
+			Technique:    SimpleColoring,
+			Fields:       fields,
+			Eliminations: eliminations,
+			Message: fmt.Sprintf("Found %s on value %d: One of the colored fields %s and %s holds it, so it is denied for the fields seeing both colors",
+				SimpleColoring, val, formatInts(fieldIndices(chain[0])), formatInts(fieldIndices(chain[1]))),
+		},
+	}
+}
+
+// anySeesEachOther checks if any two of the fields share a row, col or block
+func anySeesEachOther(s Sudoku, fields []*Field) bool {
+	for i, a := range fields {
+		if seesAny(s, a, fields[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// seesAny checks if field shares a row, col or block with any of the fields
+func seesAny(s Sudoku, field *Field, fields []*Field) bool {
+	for _, other := range fields {
+		if s.Sees(field, other) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldIndices returns the sorted indices of the given fields
+func fieldIndices(fields []*Field) []int {
+	result := make([]int, len(fields))
+	for i, field := range fields {
+		result[i] = field.Index
+	}
+	sort.Ints(result)
+	return result
+}
@@ -0,0 +1,51 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveSimpleColoringTrap(t *testing.T) {
+	s := New(3)
+	// value 5 forms the chain 0 - 4 (row 0), 4 - 76 (col 4) and 76 - 72 (row 8)
+	confine(s, 0, 5, 0, 4)
+	confine(s, 8, 5, 0, 4)
+	for _, f := range s.cols[4].Fields {
+		if f.Index != 4 && f.Index != 76 {
+			f.DenyValue(5)
+		}
+	}
+
+	res := s.SolveSimpleColoring()
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, SimpleColoring, res.Step.Technique)
+	assert.Equal(t, []int{0, 4, 72, 76}, res.Step.Fields)
+	// the rest of col 0 sees 0 and 72, which have different colors
+	assert.Len(t, res.Step.Eliminations, 7)
+	assert.Contains(t, res.Step.Eliminations, Elimination{Field: 9, Value: 5})
+	assert.True(t, s.Fields[9].NonValues.Contains(5))
+}
+
+func TestSolveSimpleColoringWrap(t *testing.T) {
+	s := New(3)
+	// value 5 forms the chain 0 - 4 (row 0), 4 - 40 (col 4), 40 - 37 (row 4)
+	// and 37 - 10 (col 1), so 0 and 10 get the same color inside block 0
+	confine(s, 0, 5, 0, 4)
+	confine(s, 4, 5, 1, 4)
+	for _, f := range s.cols[4].Fields {
+		if f.Index != 4 && f.Index != 40 {
+			f.DenyValue(5)
+		}
+	}
+	for _, f := range s.cols[1].Fields {
+		if f.Index != 10 && f.Index != 37 {
+			f.DenyValue(5)
+		}
+	}
+
+	res := s.SolveSimpleColoring()
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, []int{0, 4, 10, 37, 40}, res.Step.Fields)
+	assert.Equal(t, []Elimination{{Field: 0, Value: 5}, {Field: 40, Value: 5}, {Field: 10, Value: 5}}, res.Step.Eliminations)
+}
@@ -0,0 +1,288 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+)
+
+// Backend is an algorithm used to solve a sudoku once deduction stalls
+type Backend int
+
+const (
+	// DancingLinks solves the sudoku as exact cover problem using Knuth's
+	// Algorithm X with Dancing Links
+	DancingLinks Backend = iota
+	// Backtracking tries all possible values field by field
+	Backtracking
+)
+
+var backendNames = map[Backend]string{
+	DancingLinks: "dlx",
+	Backtracking: "backtracking",
+}
+
+// ParseBackend parses the name of a Backend (dlx, backtracking)
+func ParseBackend(name string) (Backend, error) {
+	for backend, backendName := range backendNames {
+		if backendName == name {
+			return backend, nil
+		}
+	}
+	return DancingLinks, fmt.Errorf("unknown backend: %s", name)
+}
+
+// String returns the name of the Backend
+func (b Backend) String() string {
+	return backendNames[b]
+}
+
+// SolveDancingLinks solves the sudoku using Dancing Links, returns false if
+// there is no solution
+func (s Sudoku) SolveDancingLinks(options SolveOptions) bool {
+	d := newDancingLinks(s)
+	d.ctx = options.ctx
+	d.onGuess = func(c candidate) {
+		options.guess(c.field, c.value)
+	}
+	found := d.solve(1)
+	if options.Stats != nil {
+		options.Stats.Nodes += d.nodes
+		options.Stats.Backtracks += d.backtracks
+	}
+	if found == 0 {
+		return false
+	}
+	s.history.begin()
+	defer s.history.end()
+	for _, c := range d.solution {
+		if f := s.Fields[c.field]; !f.IsSolved() {
+			s.addSolution(f, c.value)
+		}
+	}
+	return true
+}
+
+// candidate is a value for a field, a row of the exact cover matrix
+type candidate struct {
+	field int
+	value int
+}
+
+// dancingLinks is a sparse exact cover matrix. Nodes are stored as indices
+// into the link slices, node 0 is the root and nodes 1 to the number of
+// constraints are the column headers.
+type dancingLinks struct {
+	left, right, up, down []int
+	column                []int
+	// row holds the candidate of every node
+	row []candidate
+	// size holds the number of nodes of every column
+	size []int
+	// covered marks columns already satisfied
+	covered []bool
+
+	// givens are the rows selected when building the matrix
+	givens []candidate
+	// unsolvable is set if the givens conflict
+	unsolvable bool
+
+	partial    []candidate
+	solution   []candidate
+	count      int
+	nodes      int
+	backtracks int
+	onGuess    func(candidate)
+	// ctx stops the search if set and done
+	ctx context.Context
+	// maxNodes stops the search after this many nodes if > 0, exhausted is
+	// set then
+	maxNodes  int
+	exhausted bool
+}
+
+// cancelCheckInterval is the number of search nodes between checks of ctx
+const cancelCheckInterval = 1024
+
+// newDancingLinks builds the exact cover matrix of a sudoku. Every field
+// needs exactly one value, every row, col and block needs every value once.
+// Givens are selected right away, conflicting givens make the matrix
+// unsolvable.
+func newDancingLinks(s Sudoku) *dancingLinks {
+	n := s.MaxValue
+	columns := 4 * n * n
+	d := &dancingLinks{
+		size:    make([]int, columns+1),
+		covered: make([]bool, columns+1),
+	}
+	for i := 0; i <= columns; i++ {
+		d.left = append(d.left, (i+columns)%(columns+1))
+		d.right = append(d.right, (i+1)%(columns+1))
+		d.up = append(d.up, i)
+		d.down = append(d.down, i)
+		d.column = append(d.column, i)
+		d.row = append(d.row, candidate{})
+	}
+
+	givens := make([]int, 0)
+	for _, f := range s.Fields {
+		values := f.Candidates().Values()
+		if f.IsSolved() {
+			values = []int{f.Value}
+		}
+		row, col := f.Index/n, f.Index%n
+		block := (row/s.Size)*s.Size + col/s.Size
+		for _, v := range values {
+			first := d.addRow(candidate{field: f.Index, value: v}, []int{
+				1 + f.Index,
+				1 + n*n + row*n + v - 1,
+				1 + 2*n*n + col*n + v - 1,
+				1 + 3*n*n + block*n + v - 1,
+			})
+			if f.IsSolved() {
+				givens = append(givens, first)
+			}
+		}
+	}
+
+	for _, node := range givens {
+		if !d.selectRow(node) {
+			d.unsolvable = true
+			break
+		}
+	}
+	return d
+}
+
+// addRow appends a row covering the given columns, returns its first node
+func (d *dancingLinks) addRow(c candidate, columns []int) int {
+	first := len(d.column)
+	for i, col := range columns {
+		node := first + i
+		d.left = append(d.left, first+(i+len(columns)-1)%len(columns))
+		d.right = append(d.right, first+(i+1)%len(columns))
+		d.up = append(d.up, d.up[col])
+		d.down = append(d.down, col)
+		d.down[d.up[col]] = node
+		d.up[col] = node
+		d.column = append(d.column, col)
+		d.row = append(d.row, c)
+		d.size[col]++
+	}
+	return first
+}
+
+// selectRow permanently chooses the row of node, returns false if one of its
+// columns is already covered
+func (d *dancingLinks) selectRow(node int) bool {
+	for j := node; ; {
+		if d.covered[d.column[j]] {
+			return false
+		}
+		j = d.right[j]
+		if j == node {
+			break
+		}
+	}
+	for j := node; ; {
+		d.cover(d.column[j])
+		j = d.right[j]
+		if j == node {
+			break
+		}
+	}
+	d.givens = append(d.givens, d.row[node])
+	return true
+}
+
+func (d *dancingLinks) cover(col int) {
+	d.covered[col] = true
+	d.right[d.left[col]] = d.right[col]
+	d.left[d.right[col]] = d.left[col]
+	for i := d.down[col]; i != col; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]] = d.down[j]
+			d.up[d.down[j]] = d.up[j]
+			d.size[d.column[j]]--
+		}
+	}
+}
+
+func (d *dancingLinks) uncover(col int) {
+	for i := d.up[col]; i != col; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.size[d.column[j]]++
+			d.down[d.up[j]] = j
+			d.up[d.down[j]] = j
+		}
+	}
+	d.right[d.left[col]] = col
+	d.left[d.right[col]] = col
+	d.covered[col] = false
+}
+
+// solve searches for solutions until limit solutions have been found (no
+// limit if limit < 1) and returns the number of solutions found. The first
+// solution found is kept in d.solution.
+func (d *dancingLinks) solve(limit int) int {
+	d.partial = append([]candidate{}, d.givens...)
+	d.solution = nil
+	d.count = 0
+	if !d.unsolvable {
+		d.search(limit)
+	}
+	return d.count
+}
+
+// search returns true if the search should stop
+func (d *dancingLinks) search(limit int) bool {
+	d.nodes++
+	if d.ctx != nil && d.nodes%cancelCheckInterval == 0 && d.ctx.Err() != nil {
+		return true
+	}
+	if d.maxNodes > 0 && d.nodes > d.maxNodes {
+		d.exhausted = true
+		return true
+	}
+	if d.right[0] == 0 {
+		d.count++
+		if d.count == 1 {
+			d.solution = append([]candidate{}, d.partial...)
+		}
+		return limit > 0 && d.count >= limit
+	}
+
+	// choose the column with the fewest rows
+	col := d.right[0]
+	for c := d.right[col]; c != 0; c = d.right[c] {
+		if d.size[c] < d.size[col] {
+			col = c
+		}
+	}
+	if d.size[col] == 0 {
+		return false
+	}
+
+	guess := d.size[col] > 1
+	d.cover(col)
+	for r := d.down[col]; r != col; r = d.down[r] {
+		if guess && d.onGuess != nil {
+			d.onGuess(d.row[r])
+		}
+		d.partial = append(d.partial, d.row[r])
+		for j := d.right[r]; j != r; j = d.right[j] {
+			d.cover(d.column[j])
+		}
+		stop := d.search(limit)
+		for j := d.left[r]; j != r; j = d.left[j] {
+			d.uncover(d.column[j])
+		}
+		d.partial = d.partial[:len(d.partial)-1]
+		if stop {
+			d.uncover(col)
+			return true
+		}
+		d.backtracks++
+	}
+	d.uncover(col)
+	return false
+}
@@ -0,0 +1,60 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveDancingLinks(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	guesses := 0
+	solved := s.SolveDancingLinks(SolveOptions{OnStep: func(step Step) {
+		assert.Equal(t, BruteForce, step.Technique)
+		guesses++
+	}})
+	assert.True(t, solved)
+	assert.True(t, s.IsValidSolution())
+	assert.True(t, guesses > 0)
+}
+
+func TestSolveDancingLinksUnsolvable(t *testing.T) {
+	// conflicting givens are rejected by FromReader
+	s := New(2).Init([]int{
+		1, 2, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	})
+	assert.False(t, s.SolveDancingLinks(SolveOptions{}))
+	assert.Equal(t, 3, s.SolvedFieldCount())
+}
+
+func TestSolveDancingLinksLarge(t *testing.T) {
+	for _, size := range []int{4, 5} {
+		s := Generate(size, GenerateOptions{Seed: 1, Clues: size * size * size * size * 6 / 10})
+		assert.Equal(t, 1, s.CountSolutions(0))
+		s.Solve(SolveOptions{DontDeduce: true})
+		assert.True(t, s.IsValidSolution())
+	}
+}
+
+func TestSolveBackend(t *testing.T) {
+	for _, backend := range []Backend{DancingLinks, Backtracking} {
+		s, _ := FromFile("testfiles/hard.sudoku")
+		stats := &SolveStats{}
+		s.Solve(SolveOptions{Backend: backend, Stats: stats})
+		assert.True(t, s.IsValidSolution(), "%s", backend)
+		assert.True(t, stats.Searched > 0)
+	}
+}
+
+func TestParseBackend(t *testing.T) {
+	backend, err := ParseBackend("backtracking")
+	assert.Nil(t, err)
+	assert.Equal(t, Backtracking, backend)
+	assert.Equal(t, "dlx", DancingLinks.String())
+
+	_, err = ParseBackend("quantum")
+	assert.NotNil(t, err)
+}
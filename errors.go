@@ -0,0 +1,104 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LengthError is returned if the number of fields read doesn't match any
+// sudoku (16, 81, 256, ... fields)
+type LengthError struct {
+	Length int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("invalid sudoku: %d fields can't form a square grid", e.Length)
+}
+
+// SizeError is returned if the grid read is square, but its line size is
+// not a square number or too big
+type SizeError struct {
+	LineSize int
+}
+
+func (e *SizeError) Error() string {
+	size := isqrt(e.LineSize)
+	if size*size != e.LineSize {
+		return fmt.Sprintf("invalid sudoku: line size %d is not a square number", e.LineSize)
+	}
+	return fmt.Sprintf("invalid sudoku: size %d exceeds maximum size %d", size, MaxSize)
+}
+
+// ValueError is returned if a given is not a valid value for the sudoku
+type ValueError struct {
+	Row, Col int
+	Value    int
+	MaxValue int
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("invalid sudoku: value %d at row %d, col %d is out of range 1-%d", e.Value, e.Row, e.Col, e.MaxValue)
+}
+
+// SymbolError is returned if a token can't be read as value
+type SymbolError struct {
+	Row, Col int
+	Symbol   string
+}
+
+func (e *SymbolError) Error() string {
+	return fmt.Sprintf("invalid sudoku: unknown symbol %q at row %d, col %d", e.Symbol, e.Row, e.Col)
+}
+
+// DuplicateError is returned if a value is given more than once in a row,
+// col or block
+type DuplicateError struct {
+	Value int
+	// Group is the name of the row, col or block
+	Group string
+	// Fields are the indices of the fields holding the value
+	Fields []int
+	// lineSize is needed to describe the positions
+	lineSize int
+}
+
+func (e *DuplicateError) Error() string {
+	positions := make([]string, len(e.Fields))
+	for i, index := range e.Fields {
+		positions[i] = fmt.Sprintf("(row %d, col %d)", index/e.lineSize, index%e.lineSize)
+	}
+	return fmt.Sprintf("invalid sudoku: value %d is given more than once in %s at %s", e.Value, e.Group, strings.Join(positions, ", "))
+}
+
+// Validate checks that no value is given more than once in any row, col or
+// block, the first conflict is returned as *DuplicateError
+func (s Sudoku) Validate() error {
+	for _, group := range s.groups() {
+		fields := make(map[int][]int)
+		for _, f := range group.Fields {
+			if f.IsSolved() {
+				fields[f.Value] = append(fields[f.Value], f.Index)
+			}
+		}
+		for value := 1; value <= s.MaxValue; value++ {
+			if len(fields[value]) > 1 {
+				return &DuplicateError{
+					Value:    value,
+					Group:    group.Name,
+					Fields:   fields[value],
+					lineSize: s.MaxValue,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isqrt returns the integer square root of n
+func isqrt(n int) int {
+	root := 0
+	for (root+1)*(root+1) <= n {
+		root++
+	}
+	return root
+}
@@ -9,24 +9,34 @@ import (
 type Field struct {
 	Index     int
 	Value     int
-	NonValues *IntSet
+	NonValues BitSet
 	sudoku    *Sudoku
+	// given is set for values of the puzzle as opposed to values deduced
+	given bool
+	// propagated is the value last denied for all other fields of the same
+	// row, col and block
+	propagated int
 }
 
 // NewField creates a new Field
 func NewField(sudoku *Sudoku, index, value int) Field {
 	f := Field{
-		sudoku:    sudoku,
-		Index:     index,
-		Value:     value,
-		NonValues: NewIntSet(),
+		sudoku: sudoku,
+		Index:  index,
+		Value:  value,
 	}
 	return f
 }
 
-// DenyValue denies a value
-func (f *Field) DenyValue(value int) {
-	f.NonValues.Add(value)
+// DenyValue denies a value, returns false if it was denied already
+func (f *Field) DenyValue(value int) bool {
+	if !f.NonValues.Add(value) {
+		return false
+	}
+	if f.sudoku != nil {
+		f.sudoku.history.record(change{field: f.Index, denied: value})
+	}
+	return true
 }
 
 // Solve solves this Field
@@ -35,15 +45,15 @@ func (f *Field) Solve() bool {
 	if !f.Solvable() {
 		return false
 	}
-	for i := 1; i <= f.sudoku.MaxValue; i++ {
-		if !f.NonValues.Contains(i) {
-			f.sudoku.addSolution(f, i)
-			break
-		}
-	}
+	f.sudoku.addSolution(f, f.Candidates().Values()[0])
 	return true
 }
 
+// IsGiven checks if the value of the Field is part of the puzzle
+func (f Field) IsGiven() bool {
+	return f.given
+}
+
 // IsSolved checks if the Field is solved
 func (f Field) IsSolved() bool {
 	return f.Value != 0
@@ -51,19 +61,17 @@ func (f Field) IsSolved() bool {
 
 // Solvable checks if the Field can be solved (all other values excluded)
 func (f Field) Solvable() bool {
-	return len(f.NonValues.set) == f.sudoku.MaxValue-1
+	return f.Candidates().Len() == 1
+}
+
+// Candidates returns the set of possible values for this field
+func (f Field) Candidates() BitSet {
+	return fullBitSet(f.sudoku.MaxValue) &^ f.NonValues
 }
 
 // PossibleValues returns the list of possible values for this field
 func (f Field) PossibleValues() []int {
-	result := make([]int, 0)
-	for p := 1; p <= f.sudoku.MaxValue; p++ {
-		if f.NonValues.Contains(p) {
-			continue
-		}
-		result = append(result, p)
-	}
-	return result
+	return f.Candidates().Values()
 }
 
 // String returns a human-friendly value
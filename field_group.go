@@ -29,6 +29,71 @@ func (f FieldGroup) IsSolved() bool {
 	return true
 }
 
+// contains checks if the field is part of this group
+func (f FieldGroup) contains(field *Field) bool {
+	for _, member := range f.Fields {
+		if member == field {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAll checks if all fields are part of this group
+func (f FieldGroup) containsAll(fields []*Field) bool {
+	for _, field := range fields {
+		if !f.contains(field) {
+			return false
+		}
+	}
+	return true
+}
+
+// possibleFields returns the unsolved fields that could be of the given
+// value, nil if the value is already placed in this group
+func (f FieldGroup) possibleFields(val int) []*Field {
+	result := make([]*Field, 0)
+	for _, field := range f.Fields {
+		if field.Value == val {
+			return nil
+		}
+		if !field.IsSolved() && !field.NonValues.Contains(val) {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// valuePositions returns for every value the positions (starting at 1) of
+// the unsolved fields that could be of that value, indexed by value. The
+// positions of values already placed in this group are empty.
+func (f FieldGroup) valuePositions() []BitSet {
+	positions := make([]BitSet, f.sudoku.MaxValue+1)
+	var placed BitSet
+	for i, field := range f.Fields {
+		if field.IsSolved() {
+			placed.Add(field.Value)
+			continue
+		}
+		for _, v := range field.Candidates().Values() {
+			positions[v].Add(i + 1)
+		}
+	}
+	for _, v := range placed.Values() {
+		positions[v] = 0
+	}
+	return positions
+}
+
+// fieldsAt returns the fields at the given positions (starting at 1)
+func (f FieldGroup) fieldsAt(positions BitSet) []*Field {
+	result := make([]*Field, 0, positions.Len())
+	for _, position := range positions.Values() {
+		result = append(result, f.Fields[position-1])
+	}
+	return result
+}
+
 // Solve solves the group if possible (one step!)
 func (f FieldGroup) Solve() SolvingResult {
 	// loop possible values
@@ -53,10 +118,17 @@ valueLoop:
 		}
 		// if exactly one option, solve!
 		if deducedField != -1 {
-			f.sudoku.addSolutionByIndex(deducedField, val)
+			eliminations := f.sudoku.addSolutionByIndex(deducedField, val)
 			return SolvingResult{
 				FoundNew: true,
-				Message:  fmt.Sprintf("Deduced by checking %s: Field %d must be of value %d", f.Name, deducedField, val),
+				Step: Step{
+					Technique:    HiddenSingle,
+					Fields:       []int{deducedField},
+					Value:        val,
+					Eliminations: eliminations,
+					Group:        f.Name,
+					Message:      fmt.Sprintf("Deduced by checking %s: Field %d must be of value %d", f.Name, deducedField, val),
+				},
 			}
 		}
 	}
@@ -74,3 +146,129 @@ func (f FieldGroup) String() string {
 	}
 	return result
 }
+
+// SolveNakedSubset finds n fields that share only n possible values between
+// them (naked pair/triple/quad) and denies those values for all other fields
+// of this group (one step!)
+func (f FieldGroup) SolveNakedSubset(n int) SolvingResult {
+	candidates := make([]*Field, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		if field.IsSolved() {
+			continue
+		}
+		count := field.Candidates().Len()
+		if count >= 2 && count <= n {
+			candidates = append(candidates, field)
+		}
+	}
+
+	var res SolvingResult
+	combinations(len(candidates), n, func(combination []int) bool {
+		var values BitSet
+		subset := make([]int, n)
+		for i, c := range combination {
+			subset[i] = candidates[c].Index
+			values |= candidates[c].Candidates()
+		}
+		if values.Len() != n {
+			return true
+		}
+
+		eliminations := make([]Elimination, 0)
+		for _, field := range f.Fields {
+			if field.IsSolved() || containsInt(subset, field.Index) {
+				continue
+			}
+			for _, v := range values.Values() {
+				if field.DenyValue(v) {
+					eliminations = append(eliminations, Elimination{Field: field.Index, Value: v})
+				}
+			}
+		}
+		if len(eliminations) == 0 {
+			return true
+		}
+
+		technique := nakedSubsetTechniques[n]
+		res = SolvingResult{
+			FoundNew: true,
+			Step: Step{
+				Technique:    technique,
+				Fields:       subset,
+				Eliminations: eliminations,
+				Group:        f.Name,
+				Message: fmt.Sprintf("Found %s in %s: Fields %s can only be of values %s, these are denied for the other fields",
+					technique, f.Name, formatInts(subset), formatInts(values.Values())),
+			},
+		}
+		return false
+	})
+	return res
+}
+
+var nakedSubsetTechniques = map[int]TechniqueName{
+	2: NakedPair,
+	3: NakedTriple,
+	4: NakedQuad,
+}
+
+// SolveHiddenSubset finds n values that can only be placed in the same n
+// fields of this group (hidden pair/triple/quad) and denies all other values
+// for those fields (one step!)
+func (f FieldGroup) SolveHiddenSubset(n int) SolvingResult {
+	positions := f.valuePositions()
+	values := make([]int, 0, f.sudoku.MaxValue)
+	for val := 1; val <= f.sudoku.MaxValue; val++ {
+		count := positions[val].Len()
+		if count >= 2 && count <= n {
+			values = append(values, val)
+		}
+	}
+
+	var res SolvingResult
+	combinations(len(values), n, func(combination []int) bool {
+		var subsetValues, subsetPositions BitSet
+		for _, c := range combination {
+			subsetValues.Add(values[c])
+			subsetPositions |= positions[values[c]]
+		}
+		if subsetPositions.Len() != n {
+			return true
+		}
+
+		subset := make([]int, 0, n)
+		eliminations := make([]Elimination, 0)
+		for _, position := range subsetPositions.Values() {
+			field := f.Fields[position-1]
+			subset = append(subset, field.Index)
+			for _, v := range (field.Candidates() &^ subsetValues).Values() {
+				field.DenyValue(v)
+				eliminations = append(eliminations, Elimination{Field: field.Index, Value: v})
+			}
+		}
+		if len(eliminations) == 0 {
+			return true
+		}
+
+		technique := hiddenSubsetTechniques[n]
+		res = SolvingResult{
+			FoundNew: true,
+			Step: Step{
+				Technique:    technique,
+				Fields:       subset,
+				Eliminations: eliminations,
+				Group:        f.Name,
+				Message: fmt.Sprintf("Found %s in %s: Values %s can only be in fields %s, all other values are denied for them",
+					technique, f.Name, formatInts(subsetValues.Values()), formatInts(subset)),
+			},
+		}
+		return false
+	})
+	return res
+}
+
+var hiddenSubsetTechniques = map[int]TechniqueName{
+	2: HiddenPair,
+	3: HiddenTriple,
+	4: HiddenQuad,
+}
@@ -45,3 +45,70 @@ func TestFieldGroupSolve(t *testing.T) {
 	res = fg.Solve()
 	assert.False(t, res.FoundNew)
 }
+
+func TestFieldGroupSolveNakedSubset(t *testing.T) {
+	s := New(3)
+	row := s.rows[0]
+	for v := 3; v <= 9; v++ {
+		row.Fields[0].DenyValue(v)
+		row.Fields[4].DenyValue(v)
+	}
+	res := row.SolveNakedSubset(3)
+	assert.False(t, res.FoundNew, "a pair is no triple")
+
+	res = row.SolveNakedSubset(2)
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, NakedPair, res.Step.Technique)
+	assert.Equal(t, []int{0, 4}, res.Step.Fields)
+	assert.Equal(t, "row 0", res.Step.Group)
+	assert.Len(t, res.Step.Eliminations, 2*7)
+	assert.Equal(t, []int{3, 4, 5, 6, 7, 8, 9}, row.Fields[1].PossibleValues())
+	assert.Equal(t, []int{1, 2}, row.Fields[0].PossibleValues())
+
+	res = row.SolveNakedSubset(2)
+	assert.False(t, res.FoundNew)
+}
+
+func TestFieldGroupSolveNakedTriple(t *testing.T) {
+	s := New(3)
+	block := s.blocks[0]
+	// {1,2} {2,3} {1,3}
+	for v := 3; v <= 9; v++ {
+		block.Fields[0].DenyValue(v)
+	}
+	for _, v := range []int{1, 4, 5, 6, 7, 8, 9} {
+		block.Fields[1].DenyValue(v)
+	}
+	for _, v := range []int{2, 4, 5, 6, 7, 8, 9} {
+		block.Fields[5].DenyValue(v)
+	}
+	res := block.SolveNakedSubset(3)
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, NakedTriple, res.Step.Technique)
+	assert.Equal(t, []int{4, 5, 6, 7, 8, 9}, block.Fields[8].PossibleValues())
+}
+
+func TestFieldGroupSolveHiddenSubset(t *testing.T) {
+	s := New(3)
+	row := s.rows[0]
+	for _, field := range row.Fields {
+		if field.Index == 0 || field.Index == 4 {
+			continue
+		}
+		field.DenyValue(1)
+		field.DenyValue(2)
+	}
+	res := row.SolveHiddenSubset(3)
+	assert.False(t, res.FoundNew, "a pair is no triple")
+
+	res = row.SolveHiddenSubset(2)
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, HiddenPair, res.Step.Technique)
+	assert.Equal(t, []int{0, 4}, res.Step.Fields)
+	assert.Len(t, res.Step.Eliminations, 2*7)
+	assert.Equal(t, []int{1, 2}, row.Fields[0].PossibleValues())
+	assert.Equal(t, []int{1, 2}, row.Fields[4].PossibleValues())
+
+	res = row.SolveHiddenSubset(2)
+	assert.False(t, res.FoundNew)
+}
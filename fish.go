@@ -0,0 +1,110 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+var fishTechniques = map[int]TechniqueName{
+	2: XWing,
+	3: Swordfish,
+	4: Jellyfish,
+}
+
+// SolveFish finds n rows (or cols) in which a value can only be in the same
+// n cols (or rows) (X-Wing/Swordfish/Jellyfish of size 2/3/4). The value is
+// denied for all other fields of those cover cols (or rows) (one step!)
+func (s Sudoku) SolveFish(n int) SolvingResult {
+	res := s.solveFish(n, s.rows, s.cols)
+	if res.FoundNew {
+		return res
+	}
+	return s.solveFish(n, s.cols, s.rows)
+}
+
+// solveFish looks for fish with base lines taken from bases and cover lines
+// taken from covers. The position of a field inside a base line is the index
+// of its cover line.
+func (s Sudoku) solveFish(n int, bases, covers []FieldGroup) SolvingResult {
+	positions := make([][]BitSet, len(bases))
+	for i, base := range bases {
+		positions[i] = base.valuePositions()
+	}
+
+	for val := 1; val <= s.MaxValue; val++ {
+		lines := make([]int, 0, len(bases))
+		for i := range bases {
+			count := positions[i][val].Len()
+			if count >= 2 && count <= n {
+				lines = append(lines, i)
+			}
+		}
+
+		var res SolvingResult
+		combinations(len(lines), n, func(combination []int) bool {
+			var coverPositions BitSet
+			for _, c := range combination {
+				coverPositions |= positions[lines[c]][val]
+			}
+			if coverPositions.Len() != n {
+				return true
+			}
+
+			baseLines := make([]FieldGroup, n)
+			fields := make([]int, 0)
+			for i, c := range combination {
+				baseLines[i] = bases[lines[c]]
+				for _, field := range baseLines[i].fieldsAt(positions[lines[c]][val]) {
+					fields = append(fields, field.Index)
+				}
+			}
+
+			coverLines := make([]FieldGroup, 0, n)
+			eliminations := make([]Elimination, 0)
+			for _, position := range coverPositions.Values() {
+				cover := covers[position-1]
+				coverLines = append(coverLines, cover)
+				for _, field := range cover.possibleFields(val) {
+					if containsInt(fields, field.Index) {
+						continue
+					}
+					field.DenyValue(val)
+					eliminations = append(eliminations, Elimination{Field: field.Index, Value: val})
+				}
+			}
+			if len(eliminations) == 0 {
+				return true
+			}
+
+			technique := fishTechniques[n]
+			baseNames := groupNames(baseLines)
+			coverNames := groupNames(coverLines)
+			res = SolvingResult{
+				FoundNew: true,
+				Step: Step{
+					Technique:    technique,
+					Fields:       fields,
+					Eliminations: eliminations,
+					BaseGroups:   baseNames,
+					CoverGroups:  coverNames,
+					Message: fmt.Sprintf("Found %s on value %d: In %s it can only be in %s, so it is denied for the other fields of %s",
+						technique, val, strings.Join(baseNames, ", "), strings.Join(coverNames, ", "), strings.Join(coverNames, ", ")),
+				},
+			}
+			return false
+		})
+		if res.FoundNew {
+			return res
+		}
+	}
+	return SolvingResult{}
+}
+
+// groupNames returns the names of the given groups
+func groupNames(groups []FieldGroup) []string {
+	result := make([]string, len(groups))
+	for i, group := range groups {
+		result[i] = group.Name
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// confine leaves val possible only at the given cols of a row
+func confine(s *Sudoku, row, val int, cols ...int) {
+	for _, f := range s.rows[row].Fields {
+		if !containsInt(cols, f.Index%s.MaxValue) {
+			f.DenyValue(val)
+		}
+	}
+}
+
+func TestSolveFishXWing(t *testing.T) {
+	s := New(3)
+	confine(s, 1, 4, 2, 7)
+	confine(s, 5, 4, 2, 7)
+
+	res := s.SolveFish(2)
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, XWing, res.Step.Technique)
+	assert.Equal(t, []string{"row 1", "row 5"}, res.Step.BaseGroups)
+	assert.Equal(t, []string{"col 2", "col 7"}, res.Step.CoverGroups)
+	assert.Equal(t, []int{11, 16, 47, 52}, res.Step.Fields)
+	assert.Len(t, res.Step.Eliminations, 14)
+	assert.True(t, s.Fields[2].NonValues.Contains(4))
+	assert.False(t, s.Fields[11].NonValues.Contains(4))
+
+	res = s.SolveFish(2)
+	assert.False(t, res.FoundNew)
+}
+
+func TestSolveFishSwordfish(t *testing.T) {
+	s := New(3)
+	confine(s, 0, 9, 0, 3)
+	confine(s, 4, 9, 3, 6)
+	confine(s, 8, 9, 0, 6)
+
+	res := s.SolveFish(2)
+	assert.False(t, res.FoundNew)
+
+	res = s.SolveFish(3)
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, Swordfish, res.Step.Technique)
+	assert.Equal(t, []string{"row 0", "row 4", "row 8"}, res.Step.BaseGroups)
+	assert.Equal(t, []string{"col 0", "col 3", "col 6"}, res.Step.CoverGroups)
+	assert.Len(t, res.Step.Eliminations, 3*6)
+}
+
+func TestSolveFishCols(t *testing.T) {
+	s := New(3)
+	for _, f := range s.cols[3].Fields {
+		if f.Index/9 != 0 && f.Index/9 != 8 {
+			f.DenyValue(1)
+		}
+	}
+	for _, f := range s.cols[5].Fields {
+		if f.Index/9 != 0 && f.Index/9 != 8 {
+			f.DenyValue(1)
+		}
+	}
+
+	res := s.SolveFish(2)
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, []string{"col 3", "col 5"}, res.Step.BaseGroups)
+	assert.Equal(t, []string{"row 0", "row 8"}, res.Step.CoverGroups)
+}
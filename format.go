@@ -0,0 +1,411 @@
+package sudoku
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// Format is a file format for sudokus
+type Format int
+
+const (
+	// FormatAuto detects the format when reading
+	FormatAuto Format = iota
+	// FormatGrid is one character per field, rows and blocks may be
+	// separated by whitespace
+	FormatGrid
+	// FormatLine is a single line with one character per field as used by
+	// .sdk files
+	FormatLine
+	// FormatSDM holds one puzzle per line
+	FormatSDM
+	// FormatSadMan holds the puzzle, the current state and the candidates
+	// in [Puzzle], [State] and [Candidates] sections
+	FormatSadMan
+	// FormatSimpleSudoku is the .ss format of Simple Sudoku, blocks are
+	// separated by | and lines of dashes
+	FormatSimpleSudoku
+	// FormatJSON holds the full state including candidates, see MarshalJSON
+	FormatJSON
+)
+
+var formatNames = map[Format]string{
+	FormatAuto:         "auto",
+	FormatGrid:         "grid",
+	FormatLine:         "line",
+	FormatSDM:          "sdm",
+	FormatSadMan:       "sadman",
+	FormatSimpleSudoku: "ss",
+	FormatJSON:         "json",
+}
+
+// ParseFormat parses the name of a Format (auto, grid, line, sdm, sadman, ss,
+// json)
+func ParseFormat(name string) (Format, error) {
+	for format, formatName := range formatNames {
+		if formatName == name {
+			return format, nil
+		}
+	}
+	return FormatAuto, fmt.Errorf("unknown format: %s", name)
+}
+
+// String returns the name of the Format
+func (f Format) String() string {
+	return formatNames[f]
+}
+
+// sadMan section headers
+const (
+	sadManPuzzle     = "[Puzzle]"
+	sadManState      = "[State]"
+	sadManCandidates = "[Candidates]"
+)
+
+// DetectFormat guesses the format of the given data
+func DetectFormat(data []byte) Format {
+	text := string(data)
+	if strings.HasPrefix(strings.TrimSpace(text), "{") {
+		return FormatJSON
+	}
+	if strings.Contains(text, sadManPuzzle) {
+		return FormatSadMan
+	}
+	if strings.Contains(text, "|") {
+		return FormatSimpleSudoku
+	}
+	lines := contentLines(text)
+	if len(lines) == 0 {
+		return FormatGrid
+	}
+	for _, line := range lines {
+		if strings.ContainsAny(line, " \t") || !isSudokuLength(len([]rune(line))) {
+			return FormatGrid
+		}
+	}
+	if len(lines) == 1 {
+		return FormatLine
+	}
+	// a grid written one row per line, e.g. 16 lines of 16 values
+	if isGridLineSize(len(lines)) {
+		square := true
+		for _, line := range lines {
+			square = square && len([]rune(line)) == len(lines)
+		}
+		if square {
+			return FormatGrid
+		}
+	}
+	return FormatSDM
+}
+
+// isGridLineSize checks if lineSize is the number of fields per row of a
+// supported sudoku
+func isGridLineSize(lineSize int) bool {
+	size := isqrt(lineSize)
+	return size*size == lineSize && size <= MaxSize
+}
+
+// isSudokuLength checks if length is the number of fields of a sudoku
+func isSudokuLength(length int) bool {
+	lineSize := isqrt(length)
+	size := isqrt(lineSize)
+	return length > 0 && lineSize*lineSize == length && size*size == lineSize
+}
+
+// ReadLines returns the puzzles of input in FormatSDM without parsing them,
+// so every line can be parsed and its errors reported on its own
+func ReadLines(input io.Reader) ([]string, error) {
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return contentLines(string(data)), nil
+}
+
+// contentLines returns the trimmed lines of text, skipping empty lines and
+// comments starting with #
+func contentLines(text string) []string {
+	var result []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+// ReadAll reads all sudokus from input in the given format, FormatAuto
+// detects the format. Only FormatSDM holds more than one sudoku. Values are
+// written with the alphabet of the notation, tokens are supported by
+// FormatGrid only. Values given more than once are reported as
+// *DuplicateError.
+func ReadAll(input io.Reader, format Format, n Notation) ([]*Sudoku, error) {
+	sudokus, err := readAll(input, format, n)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sudokus {
+		if err := s.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return sudokus, nil
+}
+
+// Read reads the first sudoku from input like ReadAll
+func Read(input io.Reader, format Format, n Notation) (*Sudoku, error) {
+	sudokus, err := ReadAll(input, format, n)
+	if err != nil {
+		return nil, err
+	}
+	if len(sudokus) == 0 {
+		return nil, &LengthError{}
+	}
+	return sudokus[0], nil
+}
+
+// ReadUnchecked reads the first sudoku from input like Read, but accepts
+// values given more than once, e.g. grids filled in by players that Check
+// reports the conflicts of
+func ReadUnchecked(input io.Reader, format Format, n Notation) (*Sudoku, error) {
+	sudokus, err := readAll(input, format, n)
+	if err != nil {
+		return nil, err
+	}
+	if len(sudokus) == 0 {
+		return nil, &LengthError{}
+	}
+	return sudokus[0], nil
+}
+
+// readAll reads like ReadAll without checking for duplicate values
+func readAll(input io.Reader, format Format, n Notation) ([]*Sudoku, error) {
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatAuto {
+		format = DetectFormat(data)
+	}
+	if n.Tokens && format != FormatGrid {
+		return nil, fmt.Errorf("format %s doesn't support tokens", format)
+	}
+
+	var texts []string
+	switch format {
+	case FormatGrid:
+		texts = []string{string(data)}
+	case FormatLine:
+		lines := contentLines(string(data))
+		if len(lines) > 0 {
+			lines = lines[:1]
+		}
+		texts = []string{strings.Join(lines, "")}
+	case FormatSDM:
+		texts = contentLines(string(data))
+	case FormatSadMan:
+		s, err := readSadMan(string(data), n)
+		if err != nil {
+			return nil, err
+		}
+		return []*Sudoku{s}, nil
+	case FormatJSON:
+		s, err := unmarshalJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		return []*Sudoku{s}, nil
+	case FormatSimpleSudoku:
+		var rows []string
+		for _, line := range contentLines(string(data)) {
+			// frame and separator lines like *---* and |---+---|
+			if strings.Trim(line, "-+*|") == "" {
+				continue
+			}
+			rows = append(rows, strings.Replace(line, "|", "", -1))
+		}
+		texts = []string{strings.Join(rows, "\n")}
+	default:
+		return nil, fmt.Errorf("unknown format: %d", format)
+	}
+
+	result := make([]*Sudoku, 0, len(texts))
+	for _, text := range texts {
+		s, err := parse(strings.NewReader(text), n)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// readSadMan reads the sections of a SadMan sudoku. [Puzzle] holds the
+// givens, the optional [State] adds values placed so far and the optional
+// [Candidates] lists the candidates of every field, one row per line with
+// fields separated by whitespace.
+func readSadMan(text string, n Notation) (*Sudoku, error) {
+	sections := make(map[string][]string)
+	section := ""
+	for _, line := range contentLines(text) {
+		if strings.HasPrefix(line, "[") {
+			section = line
+			continue
+		}
+		sections[section] = append(sections[section], line)
+	}
+
+	s, err := parse(strings.NewReader(strings.Join(sections[sadManPuzzle], "\n")), n)
+	if err != nil {
+		return nil, err
+	}
+	if state, ok := sections[sadManState]; ok {
+		values, err := parse(strings.NewReader(strings.Join(state, "\n")), n)
+		if err != nil {
+			return nil, err
+		}
+		if values.Size != s.Size {
+			return nil, &SizeError{LineSize: values.MaxValue}
+		}
+		for i, f := range values.Fields {
+			if !s.Fields[i].IsGiven() {
+				s.Fields[i].Value = f.Value
+			}
+		}
+	}
+
+	candidates := sections[sadManCandidates]
+	if candidates == nil {
+		return s, nil
+	}
+	alphabet := n.alphabet()
+	var symbols []string
+	for _, line := range candidates {
+		symbols = append(symbols, strings.Fields(line)...)
+	}
+	if len(symbols) != len(s.Fields) {
+		return nil, &LengthError{Length: len(symbols)}
+	}
+	for i, symbol := range symbols {
+		if s.Fields[i].IsSolved() {
+			continue
+		}
+		var set BitSet
+		for _, r := range symbol {
+			value, ok := alphabet.Value(r)
+			if !ok || value > s.MaxValue {
+				return nil, &SymbolError{Row: i / s.MaxValue, Col: i % s.MaxValue, Symbol: symbol}
+			}
+			set.Add(value)
+		}
+		s.Fields[i].NonValues = fullBitSet(s.MaxValue) &^ set
+	}
+	return s, nil
+}
+
+// WriteFormat writes the sudoku in the given format, FormatAuto writes
+// FormatGrid
+func (s Sudoku) WriteFormat(w io.Writer, format Format, n Notation) error {
+	if n.Tokens && format != FormatGrid && format != FormatAuto {
+		return fmt.Errorf("format %s doesn't support tokens", format)
+	}
+	alphabet := n.alphabet()
+	if !n.Tokens && len(alphabet) < s.MaxValue {
+		return fmt.Errorf("alphabet %q has too few characters for values up to %d", alphabet, s.MaxValue)
+	}
+
+	var b bytes.Buffer
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	case FormatAuto, FormatGrid:
+		output, err := s.Print(n)
+		if err != nil {
+			return err
+		}
+		b.WriteString(output)
+	case FormatLine, FormatSDM:
+		for _, f := range s.Fields {
+			b.WriteString(fieldSymbol(f, alphabet, false))
+		}
+		b.WriteString("\n")
+	case FormatSadMan:
+		b.WriteString(sadManPuzzle + "\n")
+		s.writeRows(&b, alphabet, true)
+		b.WriteString(sadManState + "\n")
+		s.writeRows(&b, alphabet, false)
+		b.WriteString(sadManCandidates + "\n")
+		for row := 0; row < s.MaxValue; row++ {
+			symbols := make([]string, s.MaxValue)
+			for col := range symbols {
+				f := s.Fields[row*s.MaxValue+col]
+				if f.IsSolved() {
+					symbols[col] = fieldSymbol(f, alphabet, false)
+					continue
+				}
+				var candidates strings.Builder
+				for _, value := range f.PossibleValues() {
+					candidates.WriteByte(alphabet.Symbol(value))
+				}
+				symbols[col] = candidates.String()
+			}
+			b.WriteString(strings.Join(symbols, " ") + "\n")
+		}
+	case FormatSimpleSudoku:
+		// framed like *-----------* with |---+---+---| between bands
+		frame := "*" + strings.Repeat("-", s.MaxValue+s.Size-1) + "*\n"
+		separator := "|" + strings.Repeat(strings.Repeat("-", s.Size)+"+", s.Size)
+		separator = separator[:len(separator)-1] + "|\n"
+		b.WriteString(frame)
+		for row := 0; row < s.MaxValue; row++ {
+			if row > 0 && row%s.Size == 0 {
+				b.WriteString(separator)
+			}
+			b.WriteString("|")
+			for col := 0; col < s.MaxValue; col++ {
+				b.WriteString(fieldSymbol(s.Fields[row*s.MaxValue+col], alphabet, false))
+				if col%s.Size == s.Size-1 {
+					b.WriteString("|")
+				}
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(frame)
+	default:
+		return fmt.Errorf("unknown format: %d", format)
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// writeRows writes one row per line, givensOnly leaves deduced values empty
+func (s Sudoku) writeRows(b *bytes.Buffer, alphabet Alphabet, givensOnly bool) {
+	for row := 0; row < s.MaxValue; row++ {
+		for col := 0; col < s.MaxValue; col++ {
+			b.WriteString(fieldSymbol(s.Fields[row*s.MaxValue+col], alphabet, givensOnly))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// fieldSymbol returns the character written for a field
+func fieldSymbol(f *Field, alphabet Alphabet, givensOnly bool) string {
+	if !f.IsSolved() || givensOnly && !f.IsGiven() {
+		return emptySymbol
+	}
+	return string(alphabet.Symbol(f.Value))
+}
@@ -0,0 +1,140 @@
+package sudoku
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	for _, filename := range []string{"testfiles/small.sudoku", "testfiles/hard.sudoku"} {
+		data, _ := ioutil.ReadFile(filename)
+		assert.Equal(t, FormatGrid, DetectFormat(data), filename)
+	}
+	data, _ := ioutil.ReadFile("testfiles/hard.ss")
+	assert.Equal(t, FormatSimpleSudoku, DetectFormat(data))
+
+	line := strings.Repeat(".", 81)
+	assert.Equal(t, FormatLine, DetectFormat([]byte(line+"\n")))
+	assert.Equal(t, FormatSDM, DetectFormat([]byte("# two puzzles\n"+line+"\n"+line+"\n")))
+	assert.Equal(t, FormatSadMan, DetectFormat([]byte("[Puzzle]\n"+line)))
+}
+
+func TestReadSimpleSudoku(t *testing.T) {
+	grid, _ := FromFile("testfiles/hard.sudoku")
+	data, _ := ioutil.ReadFile("testfiles/hard.ss")
+	s, err := Read(bytes.NewReader(data), FormatAuto, Notation{})
+	assert.Nil(t, err)
+	assert.Equal(t, grid.values(), s.values())
+
+	var b bytes.Buffer
+	assert.Nil(t, s.WriteFormat(&b, FormatSimpleSudoku, Notation{}))
+	assert.Equal(t, string(data), b.String())
+}
+
+func TestReadSimpleSudokuPlain(t *testing.T) {
+	s, err := Read(strings.NewReader(`
+		12|..
+		..|12
+		-----
+		21|..
+		..|21
+	`), FormatAuto, Notation{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, s.Size)
+	assert.Equal(t, 8, s.SolvedFieldCount())
+}
+
+func TestReadGridRows(t *testing.T) {
+	s := Generate(4, GenerateOptions{Seed: 1, Clues: 160})
+	notation := Notation{Alphabet: HexAlphabet}
+	var b bytes.Buffer
+	assert.Nil(t, s.WriteFormat(&b, FormatLine, notation))
+	line := strings.TrimSpace(b.String())
+	var rows strings.Builder
+	for row := 0; row < s.MaxValue; row++ {
+		rows.WriteString(line[row*s.MaxValue:(row+1)*s.MaxValue] + "\n")
+	}
+
+	assert.Equal(t, FormatGrid, DetectFormat([]byte(rows.String())))
+	sudokus, err := ReadAll(strings.NewReader(rows.String()), FormatAuto, notation)
+	assert.Nil(t, err)
+	if assert.Len(t, sudokus, 1) {
+		assert.Equal(t, s.values(), sudokus[0].values())
+	}
+}
+
+func TestReadAllSDM(t *testing.T) {
+	hard, _ := FromFile("testfiles/hard.sudoku")
+	veryHard, _ := FromFile("testfiles/very-hard.sudoku")
+	var b bytes.Buffer
+	for _, s := range []*Sudoku{hard, veryHard} {
+		assert.Nil(t, s.WriteFormat(&b, FormatSDM, Notation{}))
+	}
+	assert.Equal(t, 2, strings.Count(b.String(), "\n"))
+
+	sudokus, err := ReadAll(&b, FormatAuto, Notation{})
+	assert.Nil(t, err)
+	if assert.Len(t, sudokus, 2) {
+		assert.Equal(t, hard.values(), sudokus[0].values())
+		assert.Equal(t, veryHard.values(), sudokus[1].values())
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	lines, err := ReadLines(strings.NewReader("# comment\n 1.3. \n\ninvalid\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.3.", "invalid"}, lines)
+}
+
+func TestReadLine(t *testing.T) {
+	s, err := Read(strings.NewReader("1.3.0.2.4.1.3...\n"), FormatAuto, Notation{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, s.Size)
+	assert.Equal(t, 6, s.SolvedFieldCount())
+}
+
+func TestReadSadMan(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	s.Reason()
+	for i := 0; i < 3; i++ {
+		s.SolveStep(SolveOptions{})
+	}
+	assert.Equal(t, 35, s.SolvedFieldCount())
+
+	var b bytes.Buffer
+	assert.Nil(t, s.WriteFormat(&b, FormatSadMan, Notation{}))
+	read, err := Read(&b, FormatAuto, Notation{})
+	assert.Nil(t, err)
+	for i, f := range read.Fields {
+		assert.Equal(t, s.Fields[i].Value, f.Value, "value of field %d", i)
+		assert.Equal(t, s.Fields[i].IsGiven(), f.IsGiven(), "given of field %d", i)
+		if !f.IsSolved() {
+			assert.Equal(t, s.Fields[i].Candidates(), f.Candidates(), "candidates of field %d", i)
+		}
+	}
+}
+
+func TestReadSadManConflictingState(t *testing.T) {
+	puzzle := "12..\n....\n....\n....\n"
+	_, err := Read(strings.NewReader("[Puzzle]\n"+puzzle+"[State]\n..1.\n....\n....\n....\n"), FormatAuto, Notation{})
+	assert.IsType(t, &DuplicateError{}, err)
+}
+
+func TestReadFormatTokens(t *testing.T) {
+	_, err := Read(strings.NewReader("1 2 3 4"), FormatLine, Notation{Tokens: true})
+	assert.NotNil(t, err)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("sadman")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatSadMan, format)
+	assert.Equal(t, "sadman", format.String())
+
+	_, err = ParseFormat("xml")
+	assert.NotNil(t, err)
+}
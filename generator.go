@@ -0,0 +1,171 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// Symmetry describes how the givens of a generated sudoku are arranged
+type Symmetry int
+
+const (
+	// SymmetryNone places givens without any symmetry
+	SymmetryNone Symmetry = iota
+	// SymmetryRotational places givens symmetric to the center (180 degree rotation)
+	SymmetryRotational
+	// SymmetryMirror places givens symmetric to the vertical center line
+	SymmetryMirror
+)
+
+var symmetryNames = map[Symmetry]string{
+	SymmetryNone:       "none",
+	SymmetryRotational: "rotational",
+	SymmetryMirror:     "mirror",
+}
+
+// ParseSymmetry parses the name of a Symmetry (none, rotational, mirror)
+func ParseSymmetry(name string) (Symmetry, error) {
+	for symmetry, symmetryName := range symmetryNames {
+		if symmetryName == name {
+			return symmetry, nil
+		}
+	}
+	return SymmetryNone, fmt.Errorf("unknown symmetry: %s", name)
+}
+
+// String returns the name of the Symmetry
+func (s Symmetry) String() string {
+	return symmetryNames[s]
+}
+
+// GenerateOptions controls the generation of a sudoku
+type GenerateOptions struct {
+	// Seed makes generation reproducible, equal seeds yield equal puzzles
+	Seed int64
+	// Clues is the targeted number of givens, 0 removes as many as possible
+	Clues    int
+	Symmetry Symmetry
+}
+
+// Generate creates a new sudoku of the given size with a unique solution.
+// If the targeted clue count can't be reached without losing uniqueness,
+// the resulting puzzle has more givens than requested. It panics if size is
+// out of range 1-MaxSize.
+func Generate(size int, opts GenerateOptions) *Sudoku {
+	s, err := GenerateContext(context.Background(), size, opts)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// GenerateContext generates like Generate, but returns an error for sizes
+// out of range 1-MaxSize and stops as soon as ctx is done, returning
+// ctx.Err() then
+func GenerateContext(ctx context.Context, size int, opts GenerateOptions) (*Sudoku, error) {
+	if size < 1 || size > MaxSize {
+		return nil, fmt.Errorf("sudoku size %d is out of range 1-%d", size, MaxSize)
+	}
+	random := rand.New(rand.NewSource(opts.Seed))
+	solution := generateSolution(size, random)
+	values := make([]int, len(solution))
+	copy(values, solution)
+
+	s := New(size)
+	clues := len(values)
+	for _, index := range random.Perm(len(values)) {
+		if values[index] == 0 {
+			continue
+		}
+		partner := s.symmetricIndex(index, opts.Symmetry)
+		removed := []int{index}
+		if partner != index && values[partner] != 0 {
+			removed = append(removed, partner)
+		}
+		if clues-len(removed) < opts.Clues {
+			continue
+		}
+
+		for _, r := range removed {
+			values[r] = 0
+		}
+		unique, err := isUniqueWithin(ctx, size, values)
+		if err != nil {
+			return nil, err
+		}
+		if unique {
+			clues -= len(removed)
+			continue
+		}
+		// restore, uniqueness is lost
+		for _, r := range removed {
+			values[r] = solution[r]
+		}
+	}
+
+	s.Init(values)
+	return s, nil
+}
+
+// generateNodeBudget bounds the search of every uniqueness check while
+// generating, big sudokus with few givens can take very long to check
+const generateNodeBudget = 1000
+
+// isUniqueWithin checks if values have exactly one solution, searching at
+// most generateNodeBudget nodes. A search out of budget counts as not unique.
+func isUniqueWithin(ctx context.Context, size int, values []int) (bool, error) {
+	d := newDancingLinks(New(size).Init(values))
+	d.ctx = ctx
+	d.maxNodes = generateNodeBudget
+	count := d.solve(2)
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return count == 1 && !d.exhausted, nil
+}
+
+// generateSolution builds a random completely solved grid. A valid base
+// pattern is shuffled by operations that keep it valid: swapping rows within
+// a band, swapping bands, the same for columns and stacks and relabeling
+// values.
+func generateSolution(size int, random *rand.Rand) []int {
+	lineSize := size * size
+	rows := shuffledLines(size, random)
+	cols := shuffledLines(size, random)
+	labels := random.Perm(lineSize)
+
+	values := make([]int, lineSize*lineSize)
+	for row := 0; row < lineSize; row++ {
+		for col := 0; col < lineSize; col++ {
+			r, c := rows[row], cols[col]
+			pattern := (size*(r%size) + r/size + c) % lineSize
+			values[row*lineSize+col] = labels[pattern] + 1
+		}
+	}
+	return values
+}
+
+// shuffledLines returns a random line order that keeps lines in their band
+func shuffledLines(size int, random *rand.Rand) []int {
+	result := make([]int, 0, size*size)
+	for _, band := range random.Perm(size) {
+		for _, line := range random.Perm(size) {
+			result = append(result, band*size+line)
+		}
+	}
+	return result
+}
+
+// symmetricIndex returns the index of the field paired with the given one
+func (s Sudoku) symmetricIndex(index int, symmetry Symmetry) int {
+	lineSize := s.MaxValue
+	switch symmetry {
+	case SymmetryRotational:
+		return len(s.Fields) - 1 - index
+	case SymmetryMirror:
+		row, col := index/lineSize, index%lineSize
+		return row*lineSize + lineSize - 1 - col
+	}
+	return index
+}
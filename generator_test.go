@@ -0,0 +1,79 @@
+package sudoku
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSolution(t *testing.T) {
+	s := New(3)
+	s.Init(generateSolution(3, rand.New(rand.NewSource(1))))
+	assert.True(t, s.IsSolved())
+	assert.True(t, s.IsValidSolution())
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(2, GenerateOptions{Seed: 1})
+	assert.False(t, s.IsSolved())
+	assert.True(t, s.IsUnique())
+
+	s2 := Generate(2, GenerateOptions{Seed: 1})
+	assert.Equal(t, s.String(), s2.String())
+}
+
+func TestGenerateClues(t *testing.T) {
+	s := Generate(3, GenerateOptions{Seed: 2, Clues: 40})
+	assert.Equal(t, 40, s.SolvedFieldCount())
+	assert.True(t, s.IsUnique())
+}
+
+func TestGenerateSymmetry(t *testing.T) {
+	for _, symmetry := range []Symmetry{SymmetryRotational, SymmetryMirror} {
+		s := Generate(3, GenerateOptions{Seed: 3, Clues: 36, Symmetry: symmetry})
+		assert.True(t, s.IsUnique())
+		for _, f := range s.Fields {
+			partner := s.Fields[s.symmetricIndex(f.Index, symmetry)]
+			assert.Equal(t, f.IsSolved(), partner.IsSolved(), "symmetry %s broken at field %d", symmetry, f.Index)
+		}
+	}
+}
+
+func TestGenerateLarge(t *testing.T) {
+	// takes seconds, the deadline leaves room for slow builds like -race
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	s, err := GenerateContext(ctx, 5, GenerateOptions{Seed: 1})
+	assert.Nil(t, err)
+	assert.True(t, s.IsUnique())
+	assert.True(t, s.SolvedFieldCount() < len(s.Fields)/2)
+}
+
+func TestGenerateContext(t *testing.T) {
+	s, err := GenerateContext(context.Background(), 2, GenerateOptions{Seed: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, Generate(2, GenerateOptions{Seed: 1}).values(), s.values())
+
+	for _, size := range []int{-1, 0, MaxSize + 1} {
+		_, err = GenerateContext(context.Background(), size, GenerateOptions{})
+		assert.NotNil(t, err, "size %d", size)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = GenerateContext(ctx, 5, GenerateOptions{})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestParseSymmetry(t *testing.T) {
+	symmetry, err := ParseSymmetry("mirror")
+	assert.Nil(t, err)
+	assert.Equal(t, SymmetryMirror, symmetry)
+	assert.Equal(t, "mirror", symmetry.String())
+
+	_, err = ParseSymmetry("diagonal")
+	assert.NotNil(t, err)
+}
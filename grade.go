@@ -0,0 +1,119 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+)
+
+// Difficulty is a difficulty bucket for sudokus
+type Difficulty int
+
+const (
+	// DifficultyEasy sudokus need simple singles only
+	DifficultyEasy Difficulty = iota
+	// DifficultyMedium sudokus need basic candidate eliminations
+	DifficultyMedium
+	// DifficultyHard sudokus need advanced candidate eliminations
+	DifficultyHard
+	// DifficultyExpert sudokus need the hardest techniques or guessing
+	DifficultyExpert
+)
+
+var difficultyNames = map[Difficulty]string{
+	DifficultyEasy:   "easy",
+	DifficultyMedium: "medium",
+	DifficultyHard:   "hard",
+	DifficultyExpert: "expert",
+}
+
+// String returns the name of the Difficulty
+func (d Difficulty) String() string {
+	return difficultyNames[d]
+}
+
+// bruteForceWeight rates how hard it is to solve a field by searching
+const bruteForceWeight = 20
+
+// difficultyForWeight maps the weight of the hardest technique needed to a
+// Difficulty
+func difficultyForWeight(weight int) Difficulty {
+	switch {
+	case weight <= 2:
+		return DifficultyEasy
+	case weight <= 5:
+		return DifficultyMedium
+	case weight <= 9:
+		return DifficultyHard
+	}
+	return DifficultyExpert
+}
+
+// Grading is the result of grading a sudoku
+type Grading struct {
+	// Score sums up the weights of all steps needed for solving
+	Score      int
+	Difficulty Difficulty
+	// Techniques counts the deductive steps per technique
+	Techniques map[TechniqueName]int
+	// Guesses counts the values tried while brute forcing, 0 if the sudoku
+	// could be solved by deduction only
+	Guesses int
+	// Searched counts the fields that could not be solved by deduction
+	Searched int
+}
+
+// String returns a human-friendly summary
+func (g Grading) String() string {
+	return fmt.Sprintf("%s (score %d)", g.Difficulty, g.Score)
+}
+
+// Grade solves a copy of this sudoku and rates it by the techniques needed.
+// The Difficulty is determined by the hardest technique, the Score by the
+// sum of all steps taken. Fields that need searching are rated as brute force.
+func (s Sudoku) Grade() Grading {
+	return s.GradeWith(SolveOptions{})
+}
+
+// GradeContext grades like Grade, but stops as soon as ctx is done and
+// returns ctx.Err() then
+func (s Sudoku) GradeContext(ctx context.Context) (Grading, error) {
+	grading := s.GradeWith(SolveOptions{ctx: ctx})
+	return grading, ctx.Err()
+}
+
+// GradeWith grades like Grade, but solves with the given options
+func (s Sudoku) GradeWith(opts SolveOptions) Grading {
+	stats := &SolveStats{}
+	opts.Stats = stats
+	opts.OnCopy = true
+	s.Solve(opts)
+
+	weights := make(map[TechniqueName]int)
+	for _, technique := range opts.techniques() {
+		weights[technique.Name()] = technique.Weight()
+	}
+
+	result := Grading{
+		Techniques: make(map[TechniqueName]int),
+		Guesses:    stats.Guesses,
+		Searched:   stats.Searched,
+	}
+	hardest := 0
+	for technique, count := range stats.Techniques {
+		result.Techniques[technique] = count
+		weight := weights[technique]
+		result.Score += weight * count
+		if weight > hardest {
+			hardest = weight
+		}
+	}
+	if stats.Searched > 0 {
+		weight := bruteForceWeight
+		result.Score += weight * stats.Searched
+		if weight > hardest {
+			hardest = weight
+		}
+	}
+	result.Difficulty = difficultyForWeight(hardest)
+	return result
+}
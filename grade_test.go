@@ -0,0 +1,62 @@
+package sudoku
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGradeEasy(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	g := s.Grade()
+	assert.Equal(t, DifficultyEasy, g.Difficulty)
+	assert.Equal(t, 0, g.Guesses)
+	assert.True(t, g.Techniques[HiddenSingle] > 0)
+	assert.True(t, g.Score > 0)
+	assert.False(t, s.IsSolved(), "grading must not solve the sudoku itself")
+}
+
+func TestGradeBruteForce(t *testing.T) {
+	s, _ := FromFile("testfiles/hard.sudoku")
+	g := s.Grade()
+	assert.Equal(t, DifficultyExpert, g.Difficulty)
+	assert.True(t, g.Guesses > 0)
+	assert.True(t, g.Searched > 0)
+	assert.True(t, g.Score >= g.Searched*bruteForceWeight)
+}
+
+func TestGradeOrder(t *testing.T) {
+	easy, _ := FromFile("testfiles/easy.sudoku")
+	hard, _ := FromFile("testfiles/hard.sudoku")
+	assert.True(t, easy.Grade().Score < hard.Grade().Score)
+}
+
+func TestDifficultyForWeight(t *testing.T) {
+	assert.Equal(t, DifficultyEasy, difficultyForWeight(2))
+	assert.Equal(t, DifficultyMedium, difficultyForWeight(5))
+	assert.Equal(t, DifficultyHard, difficultyForWeight(9))
+	assert.Equal(t, DifficultyExpert, difficultyForWeight(bruteForceWeight))
+	assert.Equal(t, "expert", DifficultyExpert.String())
+}
+
+func TestGradeContext(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	grading, err := s.GradeContext(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, s.Grade(), grading)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = New(4).GradeContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestGradeWith(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	g := s.GradeWith(SolveOptions{SkipHiddenSubsets: true})
+	for _, technique := range []TechniqueName{HiddenPair, HiddenTriple, HiddenQuad} {
+		assert.Equal(t, 0, g.Techniques[technique])
+	}
+	assert.True(t, s.Grade().Techniques[HiddenPair] > 0)
+}
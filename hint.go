@@ -0,0 +1,105 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HintLevel controls how much a hint reveals
+type HintLevel int
+
+const (
+	// HintLocation tells where to look
+	HintLocation HintLevel = iota
+	// HintTechnique tells where to look and which technique to use
+	HintTechnique
+	// HintAnswer explains the full step
+	HintAnswer
+)
+
+var hintLevelNames = map[HintLevel]string{
+	HintLocation:  "location",
+	HintTechnique: "technique",
+	HintAnswer:    "answer",
+}
+
+// ParseHintLevel parses the name of a HintLevel (location, technique, answer)
+func ParseHintLevel(name string) (HintLevel, error) {
+	for level, levelName := range hintLevelNames {
+		if levelName == name {
+			return level, nil
+		}
+	}
+	return HintLocation, fmt.Errorf("unknown hint level: %s", name)
+}
+
+// String returns the name of the HintLevel
+func (l HintLevel) String() string {
+	return hintLevelNames[l]
+}
+
+// Hint is the next step to take for solving a sudoku
+type Hint struct {
+	Step Step
+	// Weight is the weight of the technique of the step
+	Weight int
+}
+
+// NextHint returns the simplest step the techniques of opts can take next
+// without changing this sudoku, false if none of them is applicable. Of all
+// applicable techniques the one with the lowest weight is chosen.
+func (s Sudoku) NextHint(opts SolveOptions) (Hint, bool) {
+	hint, found, _ := s.NextHintContext(context.Background(), opts)
+	return hint, found
+}
+
+// NextHintContext finds a hint like NextHint, but stops trying techniques as
+// soon as ctx is done and returns ctx.Err() then
+func (s Sudoku) NextHintContext(ctx context.Context, opts SolveOptions) (Hint, bool, error) {
+	techniques := append([]Technique{}, opts.techniques()...)
+	sort.SliceStable(techniques, func(i, j int) bool {
+		return techniques[i].Weight() < techniques[j].Weight()
+	})
+
+	// techniques only change the view if they find a step
+	view := s.Clone()
+	view.Reason()
+	for _, technique := range techniques {
+		if err := ctx.Err(); err != nil {
+			return Hint{}, false, err
+		}
+		if step := technique.Apply(view); step != nil {
+			return Hint{Step: *step, Weight: technique.Weight()}, true, nil
+		}
+	}
+	return Hint{}, false, nil
+}
+
+// Location returns where the step was found, e.g. "row 3"
+func (h Hint) Location() string {
+	step := h.Step
+	switch {
+	case step.Group != "":
+		return step.Group
+	case len(step.BaseGroups) > 0:
+		return strings.Join(step.BaseGroups, ", ")
+	case len(step.Pivots) > 0:
+		return fmt.Sprintf("field %d", step.Pivots[0])
+	case len(step.Fields) > 0:
+		return fmt.Sprintf("field %d", step.Fields[0])
+	}
+	return fmt.Sprintf("value %d", step.Value)
+}
+
+// Message returns the hint revealing as much as the given level allows
+func (h Hint) Message(level HintLevel) string {
+	switch level {
+	case HintLocation:
+		return fmt.Sprintf("Look at %s", h.Location())
+	case HintTechnique:
+		return fmt.Sprintf("Use %s at %s", h.Step.Technique, h.Location())
+	}
+	return h.Step.Message
+}
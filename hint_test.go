@@ -0,0 +1,64 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextHint(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	before := s.Clone()
+
+	hint, found := s.NextHint(SolveOptions{})
+	assert.True(t, found)
+	assert.Equal(t, HiddenSingle, hint.Step.Technique)
+	assert.Equal(t, 1, hint.Weight)
+	assertSameState(t, before, s)
+
+	res := s.SolveStep(SolveOptions{})
+	assert.Equal(t, res.Step, hint.Step)
+}
+
+func TestNextHintSimplest(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	techniques := []Technique{
+		NewTechnique(PointingPair, 3, (*Sudoku).SolvePointing),
+		NewTechnique(HiddenSingle, 1, (*Sudoku).SolveHiddenSingle),
+	}
+	hint, found := s.NextHint(SolveOptions{Techniques: techniques})
+	assert.True(t, found)
+	assert.Equal(t, HiddenSingle, hint.Step.Technique)
+}
+
+func TestNextHintNone(t *testing.T) {
+	s, _ := FromFile("testfiles/small.sudoku")
+	_, found := s.NextHint(SolveOptions{})
+	assert.False(t, found)
+}
+
+func TestHintMessage(t *testing.T) {
+	hint := Hint{Step: Step{
+		Technique: HiddenSingle,
+		Fields:    []int{21},
+		Value:     3,
+		Group:     "row 2",
+		Message:   "Deduced by checking row 2: Field 21 must be of value 3",
+	}}
+	assert.Equal(t, "Look at row 2", hint.Message(HintLocation))
+	assert.Equal(t, "Use hidden single at row 2", hint.Message(HintTechnique))
+	assert.Equal(t, hint.Step.Message, hint.Message(HintAnswer))
+
+	hint = Hint{Step: Step{Technique: NakedSingle, Fields: []int{4}}}
+	assert.Equal(t, "Look at field 4", hint.Message(HintLocation))
+}
+
+func TestParseHintLevel(t *testing.T) {
+	level, err := ParseHintLevel("technique")
+	assert.Nil(t, err)
+	assert.Equal(t, HintTechnique, level)
+	assert.Equal(t, "technique", level.String())
+
+	_, err = ParseHintLevel("everything")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,141 @@
+package sudoku
+
+// change is a single modification of a field, either placing a value or
+// denying one
+type change struct {
+	field int
+	// value is the placed value, 0 for an elimination
+	value int
+	// denied is the eliminated value, 0 for a placement
+	denied int
+	// previous holds the value and propagated state before a placement
+	previousValue      int
+	previousPropagated int
+}
+
+// history records the changes made to a sudoku. Changes are grouped into
+// entries, every entry is one action (a placement with its eliminations,
+// a solving step, ...) that is undone and redone as a whole.
+type history struct {
+	changes []change
+	// entries holds the offset of the first change of every entry
+	entries []int
+	undone  [][]change
+	// start is the offset of the action in progress
+	start int
+	depth int
+}
+
+// begin starts an action, actions may be nested and are committed as one
+// entry when the outermost action ends
+func (h *history) begin() {
+	if h.depth == 0 {
+		h.start = len(h.changes)
+	}
+	h.depth++
+}
+
+// end finishes an action started by begin
+func (h *history) end() {
+	h.depth--
+	if h.depth > 0 || len(h.changes) == h.start {
+		return
+	}
+	h.entries = append(h.entries, h.start)
+	h.undone = h.undone[:0]
+}
+
+func (h *history) record(c change) {
+	if h.depth == 0 {
+		h.entries = append(h.entries, len(h.changes))
+		h.undone = h.undone[:0]
+	}
+	h.changes = append(h.changes, c)
+}
+
+// pop removes the last entry and returns its changes
+func (h *history) pop() []change {
+	start := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	entry := h.changes[start:]
+	h.changes = h.changes[:start]
+	return entry
+}
+
+// revert takes back the changes of an entry in reverse order
+func (s Sudoku) revert(entry []change) {
+	for i := len(entry) - 1; i >= 0; i-- {
+		c := entry[i]
+		f := s.Fields[c.field]
+		if c.denied != 0 {
+			f.NonValues.Remove(c.denied)
+			continue
+		}
+		f.Value = c.previousValue
+		f.propagated = c.previousPropagated
+	}
+}
+
+// apply makes the changes of an entry again
+func (s Sudoku) apply(entry []change) {
+	for _, c := range entry {
+		f := s.Fields[c.field]
+		if c.denied != 0 {
+			f.NonValues.Add(c.denied)
+			continue
+		}
+		f.Value = c.value
+		f.propagated = c.value
+	}
+}
+
+// Undo takes back the last placement or solving step, returns false if
+// there is nothing to undo
+func (s Sudoku) Undo() bool {
+	h := s.history
+	if len(h.entries) == 0 {
+		return false
+	}
+	entry := append([]change{}, h.pop()...)
+	s.revert(entry)
+	h.undone = append(h.undone, entry)
+	return true
+}
+
+// Redo makes the last undone placement or solving step again, returns false
+// if there is nothing to redo. Any new change discards what could be redone.
+func (s Sudoku) Redo() bool {
+	h := s.history
+	if len(h.undone) == 0 {
+		return false
+	}
+	entry := h.undone[len(h.undone)-1]
+	h.undone = h.undone[:len(h.undone)-1]
+	s.apply(entry)
+	h.entries = append(h.entries, len(h.changes))
+	h.changes = append(h.changes, entry...)
+	return true
+}
+
+// Checkpoint returns a marker of the current state for RestoreCheckpoint.
+// Checkpoints may be taken inside a solving step too, e.g. by a Technique.
+func (s Sudoku) Checkpoint() int {
+	return len(s.history.changes)
+}
+
+// RestoreCheckpoint undoes all changes made since the checkpoint was taken.
+// The undone changes can't be redone.
+func (s Sudoku) RestoreCheckpoint(checkpoint int) {
+	h := s.history
+	if checkpoint < len(h.changes) {
+		s.revert(h.changes[checkpoint:])
+		h.changes = h.changes[:checkpoint]
+		for len(h.entries) > 0 && h.entries[len(h.entries)-1] >= checkpoint {
+			h.entries = h.entries[:len(h.entries)-1]
+		}
+		if h.depth > 0 && h.start > checkpoint {
+			h.start = checkpoint
+		}
+	}
+	h.undone = h.undone[:0]
+}
@@ -0,0 +1,102 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoRedo(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	s.Reason()
+	before := s.Clone()
+
+	res := s.SolveStep(SolveOptions{})
+	assert.True(t, res.FoundNew)
+	after := s.Clone()
+	assert.Equal(t, 33, s.SolvedFieldCount())
+
+	assert.True(t, s.Undo())
+	assertSameState(t, before, s)
+
+	assert.True(t, s.Redo())
+	assertSameState(t, after, s)
+	assert.False(t, s.Redo())
+}
+
+func TestUndoDenyValue(t *testing.T) {
+	s := New(2)
+	assert.True(t, s.Fields[0].DenyValue(3))
+	assert.False(t, s.Fields[0].DenyValue(3))
+	assert.True(t, s.Undo())
+	assert.False(t, s.Fields[0].NonValues.Contains(3))
+	assert.False(t, s.Undo())
+}
+
+func TestRedoDiscardedByNewChange(t *testing.T) {
+	s := New(2)
+	s.addSolution(s.Fields[0], 1)
+	assert.True(t, s.Undo())
+	s.addSolution(s.Fields[0], 2)
+	assert.False(t, s.Redo())
+	assert.Equal(t, 2, s.Fields[0].Value)
+}
+
+func TestRestoreCheckpoint(t *testing.T) {
+	s, _ := FromFile("testfiles/hard.sudoku")
+	s.Reason()
+	before := s.Clone()
+	checkpoint := s.Checkpoint()
+
+	s.Solve(SolveOptions{Backend: Backtracking})
+	assert.True(t, s.IsValidSolution())
+
+	s.RestoreCheckpoint(checkpoint)
+	assertSameState(t, before, s)
+	assert.False(t, s.Redo())
+}
+
+func TestRestoreCheckpointInTechnique(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	s.Reason()
+	before := s.Clone()
+
+	// tries a value, takes it back and eliminates another candidate
+	tryValue := NewTechnique("try value", 1, func(s *Sudoku) SolvingResult {
+		f := s.UnsolvedFields()[0]
+		values := f.PossibleValues()
+		checkpoint := s.Checkpoint()
+		s.addSolution(f, values[0])
+		s.RestoreCheckpoint(checkpoint)
+		f.DenyValue(values[1])
+		return SolvingResult{FoundNew: true, Step: Step{Fields: []int{f.Index}}}
+	})
+	res := s.SolveStep(SolveOptions{Techniques: []Technique{tryValue}})
+	assert.True(t, res.FoundNew)
+
+	f := s.Fields[res.Step.Fields[0]]
+	assert.False(t, f.IsSolved())
+	assert.Equal(t, before.SolvedFieldCount(), s.SolvedFieldCount())
+	assert.Equal(t, before.Fields[f.Index].Candidates().Len()-1, f.Candidates().Len())
+
+	assert.True(t, s.Undo())
+	assertSameState(t, before, s)
+}
+
+func TestUndoSearch(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	s.Reason()
+	before := s.Clone()
+
+	assert.True(t, s.SolveDancingLinks(SolveOptions{}))
+	assert.True(t, s.IsValidSolution())
+	assert.True(t, s.Undo())
+	assertSameState(t, before, s)
+}
+
+func assertSameState(t *testing.T, expected, actual *Sudoku) {
+	for i, f := range actual.Fields {
+		assert.Equal(t, expected.Fields[i].Value, f.Value, "value of field %d", i)
+		assert.Equal(t, expected.Fields[i].NonValues, f.NonValues, "candidates of field %d", i)
+	}
+}
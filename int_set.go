@@ -1,6 +1,9 @@
 package sudoku
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 // IntSet is a set of integers
 type IntSet struct {
@@ -41,7 +44,9 @@ func (set *IntSet) Values() []int {
 
 // SortedValues returns all values in the set (sorted)
 func (set *IntSet) SortedValues() []int {
-	return set.Values()
+	result := set.Values()
+	sort.Ints(result)
+	return result
 }
 
 // String returns a string representation
@@ -36,3 +36,11 @@ func TestValues(t *testing.T) {
 	s.Add(3)
 	assert.Len(t, s.Values(), 1)
 }
+
+func TestSortedValues(t *testing.T) {
+	s := NewIntSet()
+	for _, v := range []int{5, 1, 9, 3} {
+		s.Add(v)
+	}
+	assert.Equal(t, []int{1, 3, 5, 9}, s.SortedValues())
+}
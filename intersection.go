@@ -0,0 +1,106 @@
+package sudoku
+
+import "fmt"
+
+// SolvePointing finds values whose possible fields inside a block all lie in
+// the same row or col (pointing pair/triple). The value is denied for the
+// rest of that row or col (one step!)
+func (s Sudoku) SolvePointing() SolvingResult {
+	for _, block := range s.blocks {
+		positions := block.valuePositions()
+		for val := 1; val <= s.MaxValue; val++ {
+			if positions[val].Len() < 2 {
+				continue
+			}
+			for i := 0; i < s.Size; i++ {
+				// inner row i and inner col i of the block
+				var line FieldGroup
+				switch {
+				case positions[val]&^s.segmentMask(i) == 0:
+					line = s.GetRow(block.Fields[i*s.Size])
+				case positions[val]&^s.strideMask(i) == 0:
+					line = s.GetCol(block.Fields[i])
+				default:
+					continue
+				}
+				res := s.denyOutside(PointingPair, block, line, val, block.fieldsAt(positions[val]))
+				if res.FoundNew {
+					return res
+				}
+			}
+		}
+	}
+	return SolvingResult{}
+}
+
+// SolveBoxLineReduction finds values whose possible fields inside a row or
+// col all lie in the same block. The value is denied for the rest of that
+// block (one step!)
+func (s Sudoku) SolveBoxLineReduction() SolvingResult {
+	lines := make([]FieldGroup, 0, len(s.rows)+len(s.cols))
+	lines = append(lines, s.rows...)
+	lines = append(lines, s.cols...)
+	for _, line := range lines {
+		positions := line.valuePositions()
+		for val := 1; val <= s.MaxValue; val++ {
+			if positions[val].Len() < 2 {
+				continue
+			}
+			for i := 0; i < s.Size; i++ {
+				if positions[val]&^s.segmentMask(i) != 0 {
+					continue
+				}
+				block := s.GetBlock(line.Fields[i*s.Size])
+				res := s.denyOutside(BoxLineReduction, line, block, val, line.fieldsAt(positions[val]))
+				if res.FoundNew {
+					return res
+				}
+			}
+		}
+	}
+	return SolvingResult{}
+}
+
+// segmentMask returns the positions of the i-th segment of Size consecutive
+// fields of a group, i.e. an inner row of a block or the part of a row or
+// col inside a block
+func (s Sudoku) segmentMask(i int) BitSet {
+	return fullBitSet(s.Size) << uint(i*s.Size)
+}
+
+// strideMask returns the positions of the i-th inner col of a block
+func (s Sudoku) strideMask(i int) BitSet {
+	var mask BitSet
+	for row := 0; row < s.Size; row++ {
+		mask.Add(row*s.Size + i + 1)
+	}
+	return mask
+}
+
+// denyOutside denies val for all fields of cover that are not part of base
+func (s Sudoku) denyOutside(technique TechniqueName, base, cover FieldGroup, val int, fields []*Field) SolvingResult {
+	eliminations := make([]Elimination, 0)
+	for _, field := range cover.Fields {
+		if field.IsSolved() || base.contains(field) {
+			continue
+		}
+		if field.DenyValue(val) {
+			eliminations = append(eliminations, Elimination{Field: field.Index, Value: val})
+		}
+	}
+	if len(eliminations) == 0 {
+		return SolvingResult{}
+	}
+
+	return SolvingResult{
+		FoundNew: true,
+		Step: Step{
+			Technique:    technique,
+			Fields:       fieldIndices(fields),
+			Eliminations: eliminations,
+			Group:        base.Name,
+			Message: fmt.Sprintf("Found %s in %s: Value %d can only be in %s there, so it is denied for the other fields of %s",
+				technique, base.Name, val, cover.Name, cover.Name),
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolvePointing(t *testing.T) {
+	s := New(3)
+	for _, f := range s.blocks[0].Fields[2:] {
+		f.DenyValue(5)
+	}
+	res := s.SolvePointing()
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, PointingPair, res.Step.Technique)
+	assert.Equal(t, "block 0", res.Step.Group)
+	assert.Equal(t, []int{0, 1}, res.Step.Fields)
+	assert.Len(t, res.Step.Eliminations, 6)
+	for col := 3; col < 9; col++ {
+		assert.True(t, s.Fields[col].NonValues.Contains(5))
+	}
+	assert.False(t, s.Fields[9*3].NonValues.Contains(5))
+
+	res = s.SolvePointing()
+	assert.False(t, res.FoundNew)
+}
+
+func TestSolveBoxLineReduction(t *testing.T) {
+	s := New(3)
+	for _, f := range s.rows[0].Fields[3:] {
+		f.DenyValue(5)
+	}
+	res := s.SolveBoxLineReduction()
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, BoxLineReduction, res.Step.Technique)
+	assert.Equal(t, "row 0", res.Step.Group)
+	assert.Equal(t, []int{0, 1, 2}, res.Step.Fields)
+	assert.Len(t, res.Step.Eliminations, 6)
+	for _, index := range []int{9, 10, 11, 18, 19, 20} {
+		assert.True(t, s.Fields[index].NonValues.Contains(5))
+	}
+
+	res = s.SolveBoxLineReduction()
+	assert.False(t, res.FoundNew)
+}
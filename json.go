@@ -0,0 +1,107 @@
+package sudoku
+
+import "encoding/json"
+
+// jsonSudoku is the JSON representation of a sudoku
+type jsonSudoku struct {
+	Size   int         `json:"size"`
+	Fields []jsonField `json:"fields"`
+}
+
+// jsonField is the JSON representation of a field. Solved fields have a
+// value, unsolved fields list their candidates.
+type jsonField struct {
+	Value int  `json:"value,omitempty"`
+	Given bool `json:"given,omitempty"`
+	// Candidates is a pointer so an empty set isn't omitted
+	Candidates *[]int `json:"candidates,omitempty"`
+}
+
+// MarshalJSON writes the size and every field with its value, whether it
+// is a given and the candidates of unsolved fields
+func (s Sudoku) MarshalJSON() ([]byte, error) {
+	result := jsonSudoku{
+		Size:   s.Size,
+		Fields: make([]jsonField, len(s.Fields)),
+	}
+	for i, f := range s.Fields {
+		if f.IsSolved() {
+			result.Fields[i] = jsonField{Value: f.Value, Given: f.IsGiven()}
+			continue
+		}
+		candidates := f.PossibleValues()
+		if candidates == nil {
+			candidates = []int{}
+		}
+		result.Fields[i] = jsonField{Candidates: &candidates}
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON restores a sudoku written by MarshalJSON. Fields without
+// candidates may have any value. Invalid data is reported as *SizeError,
+// *LengthError, *ValueError or *DuplicateError.
+func (s *Sudoku) UnmarshalJSON(data []byte) error {
+	result, err := unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if err := result.Validate(); err != nil {
+		return err
+	}
+
+	*s = *result
+	s.link()
+	return nil
+}
+
+// link points the fields and groups back to this sudoku after it was
+// copied from another one
+func (s *Sudoku) link() {
+	for _, f := range s.Fields {
+		f.sudoku = s
+	}
+	for _, groups := range [][]FieldGroup{s.rows, s.cols, s.blocks} {
+		for i := range groups {
+			groups[i].sudoku = s
+		}
+	}
+}
+
+// unmarshalJSON reads a sudoku like UnmarshalJSON without checking for
+// duplicate values
+func unmarshalJSON(data []byte) (*Sudoku, error) {
+	var input jsonSudoku
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, err
+	}
+	if input.Size < 1 || input.Size > MaxSize {
+		return nil, &SizeError{LineSize: input.Size * input.Size}
+	}
+
+	result := New(input.Size)
+	if len(input.Fields) != len(result.Fields) {
+		return nil, &LengthError{Length: len(input.Fields)}
+	}
+	for i, field := range input.Fields {
+		f := result.Fields[i]
+		row, col := i/result.MaxValue, i%result.MaxValue
+		if field.Value < 0 || field.Value > result.MaxValue {
+			return nil, &ValueError{Row: row, Col: col, Value: field.Value, MaxValue: result.MaxValue}
+		}
+		f.Value = field.Value
+		f.given = field.Given && field.Value != 0
+		if field.Candidates == nil || f.IsSolved() {
+			continue
+		}
+		var candidates BitSet
+		for _, value := range *field.Candidates {
+			if value < 1 || value > result.MaxValue {
+				return nil, &ValueError{Row: row, Col: col, Value: value, MaxValue: result.MaxValue}
+			}
+			candidates.Add(value)
+		}
+		f.NonValues = fullBitSet(result.MaxValue) &^ candidates
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package sudoku
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	s, _ := FromFile("testfiles/small.sudoku")
+	s.Fields[0].Value = 0
+	s.Fields[0].NonValues = fullBitSet(4) &^ 1
+	s.Fields[1].given = false
+
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(data), `{"size":2,"fields":[{"candidates":[1]},{"value":2},{"value":3,"given":true}`))
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	s.Reason()
+	for i := 0; i < 5; i++ {
+		s.SolveStep(SolveOptions{})
+	}
+	s.Fields[s.UnsolvedFields()[0].Index].NonValues = fullBitSet(9)
+
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	var read Sudoku
+	assert.Nil(t, json.Unmarshal(data, &read))
+	for i, f := range read.Fields {
+		assert.Equal(t, s.Fields[i].Value, f.Value, "value of field %d", i)
+		assert.Equal(t, s.Fields[i].IsGiven(), f.IsGiven(), "given of field %d", i)
+		if !f.IsSolved() {
+			assert.Equal(t, s.Fields[i].Candidates(), f.Candidates(), "candidates of field %d", i)
+		}
+	}
+
+	read.Solve(SolveOptions{})
+	assert.False(t, read.IsValidSolution())
+}
+
+func TestUnmarshalJSONSolve(t *testing.T) {
+	hard, _ := FromFile("testfiles/hard.sudoku")
+	data, _ := json.Marshal(hard)
+
+	var s Sudoku
+	assert.Nil(t, json.Unmarshal(data, &s))
+	for _, f := range s.Fields {
+		assert.True(t, f.sudoku == &s)
+	}
+	for _, group := range s.groups() {
+		assert.True(t, group.sudoku == &s)
+	}
+	s.Solve(SolveOptions{})
+	assert.True(t, s.IsValidSolution())
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	var s Sudoku
+	assert.Equal(t, &SizeError{LineSize: 81}, json.Unmarshal([]byte(`{"size":9,"fields":[]}`), &s))
+	assert.Equal(t, &LengthError{Length: 1}, json.Unmarshal([]byte(`{"size":2,"fields":[{}]}`), &s))
+
+	fields := `{"value":5}` + strings.Repeat(`,{}`, 15)
+	assert.Equal(t, &ValueError{Row: 0, Col: 0, Value: 5, MaxValue: 4}, json.Unmarshal([]byte(`{"size":2,"fields":[`+fields+`]}`), &s))
+}
+
+func TestFormatJSON(t *testing.T) {
+	s, _ := FromFile("testfiles/hard.sudoku")
+	var b bytes.Buffer
+	assert.Nil(t, s.WriteFormat(&b, FormatJSON, Notation{}))
+	assert.Equal(t, FormatJSON, DetectFormat(b.Bytes()))
+	read, err := Read(&b, FormatAuto, Notation{})
+	assert.Nil(t, err)
+	assert.Equal(t, s.values(), read.values())
+}
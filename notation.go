@@ -0,0 +1,206 @@
+package sudoku
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Alphabet holds the characters used for the values of a sudoku, the first
+// character stands for value 1. Letters are read case-insensitive.
+type Alphabet string
+
+const (
+	// DigitAlphabet is used for sudokus up to 9x9
+	DigitAlphabet Alphabet = "123456789"
+	// HexAlphabet writes the values of 16x16 sudokus as 0-9A-F
+	HexAlphabet Alphabet = "0123456789ABCDEF"
+	// HexOneAlphabet writes the values of 16x16 sudokus as 1-9A-G
+	HexOneAlphabet Alphabet = "123456789ABCDEFG"
+	// LetterAlphabet writes values as letters, for sudokus up to 25x25
+	LetterAlphabet Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXY"
+)
+
+var alphabetNames = map[Alphabet]string{
+	DigitAlphabet:  "digits",
+	HexAlphabet:    "hex",
+	HexOneAlphabet: "hex1",
+	LetterAlphabet: "letters",
+}
+
+// ParseAlphabet parses the name of an Alphabet (digits, hex, hex1, letters)
+func ParseAlphabet(name string) (Alphabet, error) {
+	for alphabet, alphabetName := range alphabetNames {
+		if alphabetName == name {
+			return alphabet, nil
+		}
+	}
+	return DigitAlphabet, fmt.Errorf("unknown alphabet: %s", name)
+}
+
+// Value returns the value of a character, false if it isn't part of the
+// alphabet
+func (a Alphabet) Value(r rune) (int, bool) {
+	index := strings.IndexRune(string(a), unicode.ToUpper(r))
+	return index + 1, index >= 0
+}
+
+// Symbol returns the character of a value
+func (a Alphabet) Symbol(value int) byte {
+	return a[value-1]
+}
+
+// AlphabetFor returns the first built-in alphabet of digits, hex1 and
+// letters that has a character for every value up to maxValue
+func AlphabetFor(maxValue int) (Alphabet, error) {
+	for _, alphabet := range []Alphabet{DigitAlphabet, HexOneAlphabet, LetterAlphabet} {
+		if len(alphabet) >= maxValue {
+			return alphabet, nil
+		}
+	}
+	return "", fmt.Errorf("no alphabet has characters for values up to %d", maxValue)
+}
+
+// emptySymbol is written for fields without a value
+const emptySymbol = "."
+
+// emptyMarkers are read as fields without a value, 0 only if it isn't in the
+// alphabet
+const emptyMarkers = ".0-_*"
+
+// Notation describes how the values of a sudoku are written
+type Notation struct {
+	// Alphabet is used for one character per field, DigitAlphabet if not set
+	Alphabet Alphabet
+	// Tokens writes whitespace separated numbers per field instead, empty
+	// fields are written as 0 or any of . - _ *
+	Tokens bool
+}
+
+func (n Notation) alphabet() Alphabet {
+	if n.Alphabet == "" {
+		return DigitAlphabet
+	}
+	return n.Alphabet
+}
+
+// Parse reads a sudoku in the given notation. With an alphabet whitespace is
+// ignored and empty fields are marked by any of . - _ * or 0 if it is not in
+// the alphabet, other characters not in the alphabet are rejected. Invalid
+// input is reported as *LengthError, *SizeError, *ValueError, *SymbolError
+// or *DuplicateError.
+func Parse(input io.Reader, n Notation) (*Sudoku, error) {
+	s, err := parse(input, n)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// parse reads a sudoku like Parse without checking for duplicate values
+func parse(input io.Reader, n Notation) (*Sudoku, error) {
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []string
+	if n.Tokens {
+		symbols = strings.Fields(string(data))
+	} else {
+		for _, r := range string(data) {
+			if !unicode.IsSpace(r) {
+				symbols = append(symbols, string(r))
+			}
+		}
+	}
+
+	lineSize := isqrt(len(symbols))
+	if lineSize == 0 || lineSize*lineSize != len(symbols) {
+		return nil, &LengthError{Length: len(symbols)}
+	}
+	size := isqrt(lineSize)
+	if size*size != lineSize || size > MaxSize {
+		return nil, &SizeError{LineSize: lineSize}
+	}
+
+	initData := make([]int, len(symbols))
+	for i, symbol := range symbols {
+		row, col := i/lineSize, i%lineSize
+		value := 0
+		if n.Tokens {
+			value, err = strconv.Atoi(symbol)
+			if err != nil {
+				if !strings.ContainsAny(symbol, emptyMarkers) || len(symbol) != 1 {
+					return nil, &SymbolError{Row: row, Col: col, Symbol: symbol}
+				}
+				continue
+			}
+		} else {
+			var ok bool
+			value, ok = n.alphabet().Value([]rune(symbol)[0])
+			if !ok {
+				if !strings.ContainsAny(symbol, emptyMarkers) {
+					return nil, &SymbolError{Row: row, Col: col, Symbol: symbol}
+				}
+				continue
+			}
+		}
+		if value < 0 || value > lineSize {
+			return nil, &ValueError{Row: row, Col: col, Value: value, MaxValue: lineSize}
+		}
+		initData[i] = value
+	}
+
+	s := New(size)
+	s.Init(initData)
+	return s, nil
+}
+
+// Print writes the sudoku in the given notation, one row per line with
+// blocks separated by spaces and empty lines. Empty fields are written as
+// dots. The output can be read by Parse with the same notation.
+func (s Sudoku) Print(n Notation) (string, error) {
+	alphabet := n.alphabet()
+	if !n.Tokens && len(alphabet) < s.MaxValue {
+		return "", fmt.Errorf("alphabet %q has too few characters for values up to %d", alphabet, s.MaxValue)
+	}
+
+	var b strings.Builder
+	lineSize := s.MaxValue
+	for row := 0; row < lineSize; row++ {
+		if row > 0 && row%s.Size == 0 {
+			b.WriteString("\n")
+		}
+		for col := 0; col < lineSize; col++ {
+			if col > 0 && col%s.Size == 0 {
+				b.WriteString(" ")
+			}
+			f := s.Fields[row*lineSize+col]
+			if !n.Tokens {
+				if f.IsSolved() {
+					b.WriteByte(alphabet.Symbol(f.Value))
+				} else {
+					b.WriteString(emptySymbol)
+				}
+				continue
+			}
+			if col > 0 {
+				b.WriteString(" ")
+			}
+			symbol := emptySymbol
+			if f.IsSolved() {
+				symbol = strconv.Itoa(f.Value)
+			}
+			fmt.Fprintf(&b, "%"+strconv.Itoa(s.FieldLength)+"s", symbol)
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
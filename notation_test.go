@@ -0,0 +1,92 @@
+package sudoku
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHex(t *testing.T) {
+	s := Generate(4, GenerateOptions{Seed: 1, Clues: 160})
+	for _, alphabet := range []Alphabet{HexAlphabet, HexOneAlphabet, LetterAlphabet} {
+		output, err := s.Print(Notation{Alphabet: alphabet})
+		assert.Nil(t, err)
+		parsed, err := Parse(strings.NewReader(output), Notation{Alphabet: alphabet})
+		assert.Nil(t, err, "%s", alphabetNames[alphabet])
+		assert.Equal(t, s.values(), parsed.values(), "%s", alphabetNames[alphabet])
+	}
+}
+
+func TestParseLowerCase(t *testing.T) {
+	s, err := Parse(strings.NewReader("a"+strings.Repeat(".", 15)), Notation{Alphabet: LetterAlphabet})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, s.Fields[0].Value)
+}
+
+func TestParseSymbols(t *testing.T) {
+	_, err := Parse(strings.NewReader(strings.Repeat("x", 81)), Notation{})
+	assert.Equal(t, &SymbolError{Row: 0, Col: 0, Symbol: "x"}, err)
+
+	_, err = FromReader(strings.NewReader("12.." + "..?." + strings.Repeat(".", 8)))
+	assert.Equal(t, &SymbolError{Row: 1, Col: 2, Symbol: "?"}, err)
+
+	s, err := Parse(strings.NewReader("1.0-_*"+strings.Repeat(".", 10)), Notation{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, s.SolvedFieldCount())
+
+	s, err = Parse(strings.NewReader("0."+strings.Repeat(".", 254)), Notation{Alphabet: HexAlphabet})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, s.Fields[0].Value)
+}
+
+func TestAlphabetFor(t *testing.T) {
+	for maxValue, expected := range map[int]Alphabet{4: DigitAlphabet, 9: DigitAlphabet, 16: HexOneAlphabet, 25: LetterAlphabet} {
+		alphabet, err := AlphabetFor(maxValue)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, alphabet, "max value %d", maxValue)
+	}
+	_, err := AlphabetFor(36)
+	assert.NotNil(t, err)
+}
+
+func TestParseTokens(t *testing.T) {
+	s, err := Parse(strings.NewReader(`
+		 1  2  3  4  5  6  7  8  9 10 11 12 13 14 15 16
+		 . 0 - _ * `+strings.Repeat(". ", 235)), Notation{Tokens: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, s.Size)
+	assert.Equal(t, 16, s.Fields[15].Value)
+	assert.Equal(t, 16, s.SolvedFieldCount())
+
+	_, err = Parse(strings.NewReader("1 2 x 4"+strings.Repeat(" .", 12)), Notation{Tokens: true})
+	assert.Equal(t, &SymbolError{Row: 0, Col: 2, Symbol: "x"}, err)
+
+	_, err = Parse(strings.NewReader("1 2 17 4"+strings.Repeat(" .", 12)), Notation{Tokens: true})
+	assert.Equal(t, &ValueError{Row: 0, Col: 2, Value: 17, MaxValue: 4}, err)
+}
+
+func TestPrint(t *testing.T) {
+	s, _ := FromFile("testfiles/small.sudoku")
+	s.Fields[0].Value = 0
+
+	output, err := s.Print(Notation{})
+	assert.Nil(t, err)
+	assert.Equal(t, ".2 34\n34 21\n\n21 43\n43 12\n", output)
+
+	output, err = s.Print(Notation{Tokens: true})
+	assert.Nil(t, err)
+	assert.Equal(t, ". 2  3 4\n3 4  2 1\n\n2 1  4 3\n4 3  1 2\n", output)
+
+	_, err = Generate(4, GenerateOptions{Seed: 1}).Print(Notation{})
+	assert.NotNil(t, err)
+}
+
+func TestParseAlphabet(t *testing.T) {
+	alphabet, err := ParseAlphabet("hex1")
+	assert.Nil(t, err)
+	assert.Equal(t, HexOneAlphabet, alphabet)
+
+	_, err = ParseAlphabet("roman")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,172 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ErrNoSolution is returned if a sudoku can't be solved
+var ErrNoSolution = errors.New("sudoku has no solution")
+
+// subproblemsPerWorker is how many subproblems SolveParallel creates per
+// worker, more subproblems balance the load better
+const subproblemsPerWorker = 4
+
+// SolveContext solves like Solve, but stops as soon as ctx is done and
+// searches with SolveParallel once deduction stalls. It returns ctx.Err() if
+// solving was cancelled and ErrNoSolution if there is no solution. With
+// OnCopy a clone is solved and this sudoku is left untouched, e.g. to only
+// collect Stats.
+func (s Sudoku) SolveContext(ctx context.Context, opts SolveOptions) error {
+	if opts.OnCopy {
+		s = *s.Clone()
+	}
+	if err := s.deduce(ctx, opts); err != nil {
+		return err
+	}
+	if s.IsSolved() {
+		return nil
+	}
+
+	unsolved := len(s.UnsolvedFields())
+	if err := s.SolveParallel(ctx, opts); err != nil {
+		return err
+	}
+	if opts.Stats != nil {
+		opts.Stats.Searched += unsolved
+	}
+	return nil
+}
+
+// SolveParallel solves the sudoku by backtracking with a pool of
+// opts.Workers goroutines. The top levels of the search tree are split into
+// subproblems that the workers pick up, the first solution found cancels
+// all other workers. OnStep is never called concurrently.
+func (s Sudoku) SolveParallel(ctx context.Context, opts SolveOptions) error {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mutex sync.Mutex
+	onStep := opts.OnStep
+	if onStep != nil {
+		opts.OnStep = func(step Step) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			onStep(step)
+		}
+	}
+
+	jobs := make(chan []int)
+	found := make(chan []int, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats := &SolveStats{}
+			workerOpts := opts
+			workerOpts.Stats = stats
+			workerOpts.ctx = ctx
+			for values := range jobs {
+				sub := New(s.Size).Init(values)
+				if sub.SolveBrute(workerOpts) {
+					select {
+					case found <- sub.values():
+						cancel()
+					default:
+					}
+				}
+			}
+			if opts.Stats != nil {
+				mutex.Lock()
+				opts.Stats.Guesses += stats.Guesses
+				opts.Stats.Nodes += stats.Nodes
+				opts.Stats.Backtracks += stats.Backtracks
+				mutex.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, values := range s.split(workers * subproblemsPerWorker) {
+		select {
+		case jobs <- values:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	select {
+	case values := <-found:
+		s.history.begin()
+		defer s.history.end()
+		for i, v := range values {
+			if f := s.Fields[i]; !f.IsSolved() {
+				s.addSolution(f, v)
+			}
+		}
+		return nil
+	default:
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return ErrNoSolution
+}
+
+// split expands the search tree breadth first by branching on the most
+// constrained field until there are at least n subproblems or nothing is
+// left to branch on. Subproblems are returned as values of all fields,
+// dead ends are dropped.
+func (s Sudoku) split(n int) [][]int {
+	frontier := [][]int{s.values()}
+	for len(frontier) < n {
+		next := make([][]int, 0, len(frontier))
+		expanded := false
+		for _, values := range frontier {
+			sub := New(s.Size).Init(values)
+			sub.Reason()
+			f, count := sub.mostConstrainedField()
+			if f == nil {
+				next = append(next, values)
+				continue
+			}
+			if count == 0 {
+				continue
+			}
+			for _, v := range f.PossibleValues() {
+				child := make([]int, len(values))
+				copy(child, values)
+				child[f.Index] = v
+				next = append(next, child)
+			}
+			expanded = true
+		}
+		frontier = next
+		if !expanded {
+			break
+		}
+	}
+	return frontier
+}
+
+// context returns the context cancelling the search, never nil
+func (opts SolveOptions) context() context.Context {
+	if opts.ctx == nil {
+		return context.Background()
+	}
+	return opts.ctx
+}
+
+// cancelled returns true if the search should be stopped
+func (opts SolveOptions) cancelled() bool {
+	return opts.ctx != nil && opts.ctx.Err() != nil
+}
@@ -0,0 +1,87 @@
+package sudoku
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveParallel(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	s.Reason()
+	stats := &SolveStats{}
+	err := s.SolveParallel(context.Background(), SolveOptions{Workers: 4, Stats: stats})
+	assert.Nil(t, err)
+	assert.True(t, s.IsValidSolution())
+	assert.True(t, stats.Nodes > 0)
+}
+
+func TestSolveParallelUnsolvable(t *testing.T) {
+	s, _ := FromReader(strings.NewReader(`
+		12 --
+		-- 3-
+
+		-- --
+		-- --
+	`))
+	err := s.SolveParallel(context.Background(), SolveOptions{Workers: 2})
+	assert.Equal(t, ErrNoSolution, err)
+	assert.Equal(t, 3, s.SolvedFieldCount())
+}
+
+func TestSolveContext(t *testing.T) {
+	s, _ := FromFile("testfiles/hard.sudoku")
+	stats := &SolveStats{}
+	err := s.SolveContext(context.Background(), SolveOptions{Stats: stats})
+	assert.Nil(t, err)
+	assert.True(t, s.IsValidSolution())
+	assert.True(t, stats.Searched > 0)
+}
+
+func TestSolveContextOnCopy(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	before := s.Clone()
+	stats := &SolveStats{}
+	err := s.SolveContext(context.Background(), SolveOptions{OnCopy: true, Stats: stats, Workers: 2})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, stats.Techniques)
+	assertSameState(t, before, s)
+}
+
+func TestSolveContextCancelled(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := s.SolveContext(ctx, SolveOptions{})
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, s.IsSolved())
+}
+
+func TestSolveContextDeadline(t *testing.T) {
+	// an empty 64x64 grid takes far longer than the deadline by backtracking
+	s := New(8)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := s.SolveContext(ctx, SolveOptions{DontDeduce: true, Workers: 2})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestSolveContextConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			s := Generate(3, GenerateOptions{Seed: seed})
+			assert.Nil(t, s.SolveContext(context.Background(), SolveOptions{DontDeduce: true, Workers: 2}))
+			assert.True(t, s.IsValidSolution())
+		}(int64(i))
+	}
+	wg.Wait()
+}
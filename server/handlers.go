@@ -0,0 +1,281 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jojomi/sudoku"
+)
+
+// puzzleRequest holds a puzzle either as text or as full JSON state
+type puzzleRequest struct {
+	// Puzzle is the puzzle as text in any format read by sudoku.Read
+	Puzzle string `json:"puzzle"`
+	// Format of Puzzle, detected if not set
+	Format string `json:"format"`
+	// Alphabet of the values in Puzzle, digits if not set
+	Alphabet string `json:"alphabet"`
+	// Sudoku is the state as written by Sudoku.MarshalJSON, it is used
+	// instead of Puzzle if set
+	Sudoku *sudoku.Sudoku `json:"sudoku"`
+}
+
+// parsePuzzle reads the puzzle of a request and checks its size
+func (s *Server) parsePuzzle(body []byte) (*sudoku.Sudoku, sudoku.Notation, error) {
+	var request puzzleRequest
+	var notation sudoku.Notation
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, notation, err
+	}
+	if request.Alphabet != "" {
+		alphabet, err := sudoku.ParseAlphabet(request.Alphabet)
+		if err != nil {
+			return nil, notation, err
+		}
+		notation.Alphabet = alphabet
+	}
+
+	puzzle := request.Sudoku
+	if puzzle == nil {
+		format := sudoku.FormatAuto
+		if request.Format != "" {
+			var err error
+			format, err = sudoku.ParseFormat(request.Format)
+			if err != nil {
+				return nil, notation, err
+			}
+		}
+		var err error
+		puzzle, err = sudoku.Read(strings.NewReader(request.Puzzle), format, notation)
+		if err != nil {
+			return nil, notation, err
+		}
+	}
+	if puzzle.Size > s.options.MaxSize {
+		return nil, notation, &httpError{http.StatusRequestEntityTooLarge, fmt.Errorf("sudoku size %d exceeds limit %d", puzzle.Size, s.options.MaxSize)}
+	}
+	return puzzle, notation, nil
+}
+
+// sudokuResponse holds a sudoku as text and as full JSON state
+type sudokuResponse struct {
+	// Line is the sudoku in line format, empty if the alphabet is too small
+	Line   string         `json:"line,omitempty"`
+	Sudoku *sudoku.Sudoku `json:"sudoku"`
+}
+
+func newSudokuResponse(s *sudoku.Sudoku, notation sudoku.Notation) sudokuResponse {
+	var b strings.Builder
+	if err := s.WriteFormat(&b, sudoku.FormatLine, notation); err != nil {
+		return sudokuResponse{Sudoku: s}
+	}
+	return sudokuResponse{Line: strings.TrimSpace(b.String()), Sudoku: s}
+}
+
+func (s *Server) handleSolve(ctx context.Context, body []byte) (interface{}, error) {
+	puzzle, notation, err := s.parsePuzzle(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := puzzle.SolveContext(ctx, sudoku.SolveOptions{}); err != nil {
+		return nil, err
+	}
+	return newSudokuResponse(puzzle, notation), nil
+}
+
+// validateResponse tells if a puzzle is well-formed and has a unique solution
+type validateResponse struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+	// Solutions counts the solutions up to 2
+	Solutions int  `json:"solutions"`
+	Unique    bool `json:"unique"`
+}
+
+func (s *Server) handleValidate(ctx context.Context, body []byte) (interface{}, error) {
+	puzzle, _, err := s.parsePuzzle(body)
+	if err != nil {
+		if _, ok := err.(*httpError); ok {
+			return nil, err
+		}
+		return validateResponse{Error: err.Error()}, nil
+	}
+	count, err := puzzle.CountSolutionsContext(ctx, 2)
+	if err != nil {
+		return nil, err
+	}
+	return validateResponse{
+		Valid:     true,
+		Solutions: count,
+		Unique:    count == 1,
+	}, nil
+}
+
+// gradeResponse is the rating of a puzzle
+type gradeResponse struct {
+	Difficulty string         `json:"difficulty"`
+	Score      int            `json:"score"`
+	Techniques map[string]int `json:"techniques"`
+	Guesses    int            `json:"guesses"`
+	Searched   int            `json:"searched"`
+}
+
+func (s *Server) handleGrade(ctx context.Context, body []byte) (interface{}, error) {
+	puzzle, _, err := s.parsePuzzle(body)
+	if err != nil {
+		return nil, err
+	}
+	count, err := puzzle.CountSolutionsContext(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, sudoku.ErrNoSolution
+	}
+	grading, err := puzzle.GradeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := gradeResponse{
+		Difficulty: grading.Difficulty.String(),
+		Score:      grading.Score,
+		Techniques: make(map[string]int),
+		Guesses:    grading.Guesses,
+		Searched:   grading.Searched,
+	}
+	for technique, count := range grading.Techniques {
+		response.Techniques[string(technique)] = count
+	}
+	return response, nil
+}
+
+// eliminationResponse is a candidate removed by a hint
+type eliminationResponse struct {
+	Field int `json:"field"`
+	Value int `json:"value"`
+}
+
+// hintRequest holds the options for a hint besides the puzzle
+type hintRequest struct {
+	// Level is how much the hint reveals, answer if not set
+	Level string `json:"level"`
+}
+
+// hintResponse is the next deductive step for a puzzle, the technique and
+// the step itself are only set if the level reveals them
+type hintResponse struct {
+	// Found is false if no technique makes progress
+	Found        bool                  `json:"found"`
+	Level        string                `json:"level,omitempty"`
+	Location     string                `json:"location,omitempty"`
+	Technique    string                `json:"technique,omitempty"`
+	Fields       []int                 `json:"fields,omitempty"`
+	Value        int                   `json:"value,omitempty"`
+	Eliminations []eliminationResponse `json:"eliminations,omitempty"`
+	Message      string                `json:"message,omitempty"`
+}
+
+func (s *Server) handleHint(ctx context.Context, body []byte) (interface{}, error) {
+	puzzle, _, err := s.parsePuzzle(body)
+	if err != nil {
+		return nil, err
+	}
+	var request hintRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+	level := sudoku.HintAnswer
+	if request.Level != "" {
+		level, err = sudoku.ParseHintLevel(request.Level)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hint, found, err := puzzle.NextHintContext(ctx, sudoku.SolveOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return hintResponse{}, nil
+	}
+	step := hint.Step
+	response := hintResponse{
+		Found:    true,
+		Level:    level.String(),
+		Location: hint.Location(),
+		Message:  hint.Message(level),
+	}
+	if level >= sudoku.HintTechnique {
+		response.Technique = string(step.Technique)
+	}
+	if level >= sudoku.HintAnswer {
+		response.Fields = step.Fields
+		response.Value = step.Value
+		for _, e := range step.Eliminations {
+			response.Eliminations = append(response.Eliminations, eliminationResponse{Field: e.Field, Value: e.Value})
+		}
+	}
+	return response, nil
+}
+
+// generateRequest holds the options for generating a puzzle
+type generateRequest struct {
+	Size int `json:"size"`
+	// Seed makes generation reproducible, random if not set
+	Seed     *int64 `json:"seed"`
+	Clues    int    `json:"clues"`
+	Symmetry string `json:"symmetry"`
+	Alphabet string `json:"alphabet"`
+}
+
+// generateResponse is a generated puzzle with the seed used
+type generateResponse struct {
+	sudokuResponse
+	Seed int64 `json:"seed"`
+}
+
+func (s *Server) handleGenerate(ctx context.Context, body []byte) (interface{}, error) {
+	request := generateRequest{Size: 3}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+	if request.Size < 1 {
+		return nil, fmt.Errorf("invalid size %d", request.Size)
+	}
+	if request.Size > s.options.MaxSize {
+		return nil, &httpError{http.StatusRequestEntityTooLarge, fmt.Errorf("sudoku size %d exceeds limit %d", request.Size, s.options.MaxSize)}
+	}
+	options := sudoku.GenerateOptions{Clues: request.Clues, Seed: time.Now().UnixNano()}
+	if request.Seed != nil {
+		options.Seed = *request.Seed
+	}
+	if request.Symmetry != "" {
+		symmetry, err := sudoku.ParseSymmetry(request.Symmetry)
+		if err != nil {
+			return nil, err
+		}
+		options.Symmetry = symmetry
+	}
+	var notation sudoku.Notation
+	if request.Alphabet != "" {
+		alphabet, err := sudoku.ParseAlphabet(request.Alphabet)
+		if err != nil {
+			return nil, err
+		}
+		notation.Alphabet = alphabet
+	}
+
+	puzzle, err := sudoku.GenerateContext(ctx, request.Size, options)
+	if err != nil {
+		return nil, err
+	}
+	return generateResponse{
+		sudokuResponse: newSudokuResponse(puzzle, notation),
+		Seed:           options.Seed,
+	}, nil
+}
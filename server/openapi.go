@@ -0,0 +1,236 @@
+package server
+
+import "net/http"
+
+// handleOpenAPI serves the OpenAPI description of the service
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(openAPIDocument))
+}
+
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "sudoku",
+    "description": "Solve, validate, grade, hint and generate sudokus",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/solve": {
+      "post": {
+        "summary": "Solve a puzzle",
+        "requestBody": {"$ref": "#/components/requestBodies/Puzzle"},
+        "responses": {
+          "200": {
+            "description": "The solved puzzle",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/SudokuResponse"}}}
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"},
+          "503": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/validate": {
+      "post": {
+        "summary": "Check a puzzle for errors and a unique solution",
+        "requestBody": {"$ref": "#/components/requestBodies/Puzzle"},
+        "responses": {
+          "200": {
+            "description": "The validation result, invalid puzzles are reported with valid set to false",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ValidateResponse"}}}
+          },
+          "413": {"$ref": "#/components/responses/Error"},
+          "503": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/grade": {
+      "post": {
+        "summary": "Rate the difficulty of a puzzle by the techniques needed",
+        "requestBody": {"$ref": "#/components/requestBodies/Puzzle"},
+        "responses": {
+          "200": {
+            "description": "The rating",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GradeResponse"}}}
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"},
+          "503": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/hint": {
+      "post": {
+        "summary": "Find the next deductive step without applying it",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/HintRequest"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "The next step, found is false if no technique makes progress",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/HintResponse"}}}
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"},
+          "503": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/generate": {
+      "post": {
+        "summary": "Generate a puzzle with a unique solution",
+        "requestBody": {
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GenerateRequest"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "The generated puzzle",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GenerateResponse"}}}
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"},
+          "503": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "This document",
+        "responses": {"200": {"description": "The OpenAPI description"}}
+      }
+    }
+  },
+  "components": {
+    "requestBodies": {
+      "Puzzle": {
+        "required": true,
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PuzzleRequest"}}}
+      }
+    },
+    "responses": {
+      "Error": {
+        "description": "The request failed",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      }
+    },
+    "schemas": {
+      "PuzzleRequest": {
+        "type": "object",
+        "properties": {
+          "puzzle": {"type": "string", "description": "The puzzle as text, e.g. 81 characters with . for empty fields"},
+          "format": {"type": "string", "enum": ["auto", "grid", "line", "sdm", "sadman", "ss", "json"]},
+          "alphabet": {"type": "string", "enum": ["digits", "hex", "hex1", "letters"]},
+          "sudoku": {"$ref": "#/components/schemas/Sudoku"}
+        }
+      },
+      "Sudoku": {
+        "type": "object",
+        "description": "The full state of a sudoku",
+        "required": ["size", "fields"],
+        "properties": {
+          "size": {"type": "integer", "description": "Box size, 3 for a 9x9 grid"},
+          "fields": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "value": {"type": "integer"},
+                "given": {"type": "boolean"},
+                "candidates": {"type": "array", "items": {"type": "integer"}}
+              }
+            }
+          }
+        }
+      },
+      "SudokuResponse": {
+        "type": "object",
+        "properties": {
+          "line": {"type": "string"},
+          "sudoku": {"$ref": "#/components/schemas/Sudoku"}
+        }
+      },
+      "ValidateResponse": {
+        "type": "object",
+        "properties": {
+          "valid": {"type": "boolean"},
+          "error": {"type": "string"},
+          "solutions": {"type": "integer", "description": "Number of solutions, counted up to 2"},
+          "unique": {"type": "boolean"}
+        }
+      },
+      "GradeResponse": {
+        "type": "object",
+        "properties": {
+          "difficulty": {"type": "string", "enum": ["easy", "medium", "hard", "expert"]},
+          "score": {"type": "integer"},
+          "techniques": {"type": "object", "additionalProperties": {"type": "integer"}},
+          "guesses": {"type": "integer"},
+          "searched": {"type": "integer"}
+        }
+      },
+      "HintRequest": {
+        "allOf": [
+          {"$ref": "#/components/schemas/PuzzleRequest"},
+          {
+            "type": "object",
+            "properties": {
+              "level": {"type": "string", "enum": ["location", "technique", "answer"], "default": "answer"}
+            }
+          }
+        ]
+      },
+      "HintResponse": {
+        "type": "object",
+        "description": "The technique is only set from level technique on, fields, value and eliminations for level answer",
+        "properties": {
+          "found": {"type": "boolean"},
+          "level": {"type": "string", "enum": ["location", "technique", "answer"]},
+          "location": {"type": "string", "description": "Where the step was found, e.g. row 3"},
+          "technique": {"type": "string"},
+          "fields": {"type": "array", "items": {"type": "integer"}},
+          "value": {"type": "integer"},
+          "eliminations": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {"field": {"type": "integer"}, "value": {"type": "integer"}}
+            }
+          },
+          "message": {"type": "string"}
+        }
+      },
+      "GenerateRequest": {
+        "type": "object",
+        "properties": {
+          "size": {"type": "integer", "default": 3},
+          "seed": {"type": "integer", "format": "int64"},
+          "clues": {"type": "integer"},
+          "symmetry": {"type": "string", "enum": ["none", "rotational", "mirror"]},
+          "alphabet": {"type": "string", "enum": ["digits", "hex", "hex1", "letters"]}
+        }
+      },
+      "GenerateResponse": {
+        "type": "object",
+        "properties": {
+          "line": {"type": "string"},
+          "sudoku": {"$ref": "#/components/schemas/Sudoku"},
+          "seed": {"type": "integer", "format": "int64"}
+        }
+      },
+      "Error": {
+        "type": "object",
+        "properties": {"error": {"type": "string"}}
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,175 @@
+// Package server provides an HTTP/JSON service for solving, validating,
+// grading and generating sudokus
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/jojomi/sudoku"
+)
+
+// Options limit the work done per request
+type Options struct {
+	// Timeout bounds the time spent per request, 10 seconds if not set
+	Timeout time.Duration
+	// MaxBodyBytes bounds the size of request bodies, 1 MiB if not set
+	MaxBodyBytes int64
+	// MaxSize is the biggest sudoku size accepted, 5 (25x25) if not set and
+	// at most sudoku.MaxSize
+	MaxSize int
+}
+
+// Server is an http.Handler serving the sudoku API
+type Server struct {
+	options Options
+	mux     *http.ServeMux
+}
+
+// New returns a Server with the given options
+func New(options Options) *Server {
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Second
+	}
+	if options.MaxBodyBytes <= 0 {
+		options.MaxBodyBytes = 1 << 20
+	}
+	if options.MaxSize <= 0 {
+		options.MaxSize = 5
+	}
+	if options.MaxSize > sudoku.MaxSize {
+		options.MaxSize = sudoku.MaxSize
+	}
+	s := &Server{
+		options: options,
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/solve", s.post(s.handleSolve))
+	s.mux.HandleFunc("/validate", s.post(s.handleValidate))
+	s.mux.HandleFunc("/grade", s.post(s.handleGrade))
+	s.mux.HandleFunc("/hint", s.post(s.handleHint))
+	s.mux.HandleFunc("/generate", s.post(s.handleGenerate))
+	s.mux.HandleFunc("/openapi.json", handleOpenAPI)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// errTimeout is returned if a request takes longer than Options.Timeout
+var errTimeout = errors.New("request timed out")
+
+// errCancelled is returned if a request is cancelled before it is done, e.g.
+// because the client disconnected
+var errCancelled = errors.New("request cancelled")
+
+// errInternal is returned if a handler panics
+var errInternal = &httpError{http.StatusInternalServerError, errors.New("internal error")}
+
+// httpError is an error with the HTTP status to respond with
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+// handlerFunc handles a request with a context bounded by Options.Timeout,
+// the returned value is written as JSON response
+type handlerFunc func(ctx context.Context, body []byte) (interface{}, error)
+
+// post wraps a handlerFunc accepting POST requests with a JSON body only
+func (s *Server) post(handler handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, &httpError{http.StatusMethodNotAllowed, errors.New("method not allowed")})
+			return
+		}
+		body, err := readBody(w, r, s.options.MaxBodyBytes)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), s.options.Timeout)
+		defer cancel()
+		type outcome struct {
+			response interface{}
+			err      error
+		}
+		done := make(chan outcome, 1)
+		go func() {
+			defer func() {
+				if v := recover(); v != nil {
+					log.Printf("panic handling %s: %v\n%s", r.URL.Path, v, debug.Stack())
+					done <- outcome{err: errInternal}
+				}
+			}()
+			response, err := handler(ctx, body)
+			done <- outcome{response, err}
+		}()
+		select {
+		case result := <-done:
+			if result.err != nil {
+				writeError(w, result.err)
+				return
+			}
+			writeJSON(w, http.StatusOK, result.response)
+		case <-ctx.Done():
+			writeError(w, ctx.Err())
+		}
+	}
+}
+
+// readBody reads a request body of at most limit bytes
+func readBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, error) {
+	var body json.RawMessage
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	if err := decoder.Decode(&body); err != nil {
+		if strings.Contains(err.Error(), "too large") {
+			return nil, &httpError{http.StatusRequestEntityTooLarge, errors.New("request body too large")}
+		}
+		return nil, &httpError{http.StatusBadRequest, err}
+	}
+	return body, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// errorResponse is the body of all error responses
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	var statusErr *httpError
+	switch {
+	case errors.As(err, &statusErr):
+		status = statusErr.status
+	case err == errTimeout || err == context.DeadlineExceeded:
+		status = http.StatusServiceUnavailable
+		err = errTimeout
+	case err == context.Canceled:
+		status = http.StatusServiceUnavailable
+		err = errCancelled
+	case err == sudoku.ErrNoSolution:
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
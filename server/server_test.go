@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const hardPuzzle = ".2.....1.34.6.1..8...8.9....51...27...........97...16....3.2...6..9.4..7.7.....4."
+
+func post(t *testing.T, handler http.Handler, path, body string, response interface{}) int {
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if response != nil {
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), response), recorder.Body.String())
+	}
+	return recorder.Code
+}
+
+func TestSolve(t *testing.T) {
+	var response sudokuResponse
+	status := post(t, New(Options{}), "/solve", `{"puzzle":"`+hardPuzzle+`"}`, &response)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "528743916349621758716859432851436279263197584497285163184372695635914827972568341", response.Line)
+	assert.True(t, response.Sudoku.IsValidSolution())
+}
+
+func TestSolveErrors(t *testing.T) {
+	server := New(Options{MaxSize: 2, MaxBodyBytes: 200})
+	var response errorResponse
+
+	status := post(t, server, "/solve", `{"puzzle":"12."}`, &response)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, response.Error, "3 fields")
+
+	status = post(t, server, "/solve", `{"puzzle":"12.....3........"}`, &response)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	status = post(t, server, "/solve", `{"puzzle":"`+hardPuzzle+`"}`, &response)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Contains(t, response.Error, "exceeds limit")
+
+	status = post(t, server, "/solve", `{"puzzle":"`+strings.Repeat(".", 256)+`"}`, &response)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, "request body too large", response.Error)
+
+	request := httptest.NewRequest(http.MethodGet, "/solve", nil)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestSolveTimeout(t *testing.T) {
+	server := New(Options{Timeout: time.Millisecond, MaxSize: 8})
+	var response errorResponse
+	status := post(t, server, "/solve", `{"puzzle":"`+strings.Repeat(".", 4096)+`"}`, &response)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "request timed out", response.Error)
+}
+
+func TestGenerateTimeout(t *testing.T) {
+	server := New(Options{Timeout: 10 * time.Millisecond})
+	start := time.Now()
+	status := post(t, server, "/generate", `{"size":5,"seed":1}`, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request := httptest.NewRequest(http.MethodPost, "/generate", strings.NewReader(`{"size":4}`)).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+	New(Options{}).ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "request cancelled")
+
+	status := func(err error) int {
+		recorder := httptest.NewRecorder()
+		writeError(recorder, err)
+		return recorder.Code
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, status(context.Canceled))
+	assert.Equal(t, http.StatusServiceUnavailable, status(context.DeadlineExceeded))
+	assert.Equal(t, http.StatusBadRequest, status(errors.New("bad input")))
+}
+
+func TestPanic(t *testing.T) {
+	handler := New(Options{}).post(func(ctx context.Context, body []byte) (interface{}, error) {
+		panic("broken handler")
+	})
+	var response errorResponse
+	status := post(t, handler, "/broken", `{}`, &response)
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, "internal error", response.Error)
+}
+
+func TestValidate(t *testing.T) {
+	var response validateResponse
+	post(t, New(Options{}), "/validate", `{"puzzle":"`+hardPuzzle+`"}`, &response)
+	assert.Equal(t, validateResponse{Valid: true, Solutions: 1, Unique: true}, response)
+
+	response = validateResponse{}
+	post(t, New(Options{}), "/validate", `{"puzzle":"11.............."}`, &response)
+	assert.False(t, response.Valid)
+	assert.Contains(t, response.Error, "more than once")
+
+	response = validateResponse{}
+	post(t, New(Options{}), "/validate", `{"puzzle":"`+strings.Repeat(".", 16)+`"}`, &response)
+	assert.Equal(t, validateResponse{Valid: true, Solutions: 2}, response)
+}
+
+func TestGrade(t *testing.T) {
+	var response gradeResponse
+	status := post(t, New(Options{}), "/grade", `{"puzzle":"`+hardPuzzle+`"}`, &response)
+	assert.Equal(t, http.StatusOK, status)
+	assert.NotEmpty(t, response.Difficulty)
+	assert.True(t, response.Score > 0)
+}
+
+func TestHint(t *testing.T) {
+	var response hintResponse
+	status := post(t, New(Options{}), "/hint", `{"puzzle":"`+hardPuzzle+`"}`, &response)
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, response.Found)
+	assert.NotEmpty(t, response.Technique)
+	assert.NotEmpty(t, response.Message)
+	assert.Equal(t, "answer", response.Level)
+}
+
+func TestHintLevel(t *testing.T) {
+	var response hintResponse
+	status := post(t, New(Options{}), "/hint", `{"puzzle":"`+hardPuzzle+`","level":"location"}`, &response)
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, response.Found)
+	assert.Equal(t, "Look at "+response.Location, response.Message)
+	assert.Empty(t, response.Technique)
+	assert.Empty(t, response.Fields)
+
+	response = hintResponse{}
+	post(t, New(Options{}), "/hint", `{"puzzle":"`+hardPuzzle+`","level":"technique"}`, &response)
+	assert.NotEmpty(t, response.Technique)
+	assert.Empty(t, response.Fields)
+
+	status = post(t, New(Options{}), "/hint", `{"puzzle":"`+hardPuzzle+`","level":"everything"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestHintFromState(t *testing.T) {
+	var solved sudokuResponse
+	post(t, New(Options{}), "/solve", `{"puzzle":"`+hardPuzzle+`"}`, &solved)
+	state, _ := json.Marshal(solved.Sudoku)
+
+	var response hintResponse
+	status := post(t, New(Options{}), "/hint", `{"sudoku":`+string(state)+`}`, &response)
+	assert.Equal(t, http.StatusOK, status)
+	assert.False(t, response.Found)
+}
+
+func TestGenerate(t *testing.T) {
+	var response generateResponse
+	status := post(t, New(Options{}), "/generate", `{"size":2,"seed":1}`, &response)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int64(1), response.Seed)
+	assert.Len(t, response.Line, 16)
+	assert.True(t, response.Sudoku.IsUnique())
+
+	status = post(t, New(Options{}), "/generate", `{"size":6}`, nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+}
+
+func TestOpenAPI(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	recorder := httptest.NewRecorder()
+	New(Options{}).ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var document map[string]interface{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	paths := document["paths"].(map[string]interface{})
+	for _, path := range []string{"/solve", "/validate", "/grade", "/hint", "/generate"} {
+		assert.Contains(t, paths, path)
+	}
+}
@@ -0,0 +1,35 @@
+package sudoku
+
+// Elimination is a value removed from the possible values of a field
+type Elimination struct {
+	Field int
+	Value int
+}
+
+// Step is a single step taken while solving a sudoku
+type Step struct {
+	Technique TechniqueName
+	// Fields are the indices of the fields the step is based on or placed
+	Fields []int
+	// Value is the value placed, 0 if the step only eliminates values
+	Value int
+	// Eliminations are the values removed from fields by this step
+	Eliminations []Elimination
+	// Group is the name of the row, col or block the step was found in
+	Group string
+	// BaseGroups are the names of the lines a fish was found in
+	BaseGroups []string
+	// CoverGroups are the names of the lines a fish eliminates values from
+	CoverGroups []string
+	// Pivots are the indices of the pivot fields of a wing
+	Pivots []int
+	// Pincers are the indices of the pincer fields of a wing
+	Pincers []int
+	// Message is a human-friendly explanation
+	Message string
+}
+
+// String returns a human-friendly explanation
+func (s Step) String() string {
+	return s.Message
+}
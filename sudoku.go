@@ -1,14 +1,13 @@
 package sudoku
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"math"
 	"strconv"
 	"strings"
-	"unicode"
 )
 
 // Optimization ideas:
@@ -16,6 +15,9 @@ import (
 // - auto solve on adding n-1th element in deny list
 // - dirty state for row/col/block?
 
+// MaxSize is the biggest supported size (64 values per row)
+const MaxSize = 8
+
 // Sudoku is a sudoku puzzle
 type Sudoku struct {
 	Size        int
@@ -25,19 +27,84 @@ type Sudoku struct {
 	cols        []FieldGroup
 	rows        []FieldGroup
 	blocks      []FieldGroup
+	history     *history
 }
 
 type SolveOptions struct {
-	PrintSteps bool
 	DeduceOnly bool
 	DontDeduce bool
+	// Backend is used once deduction stalls
+	Backend Backend
+	// Techniques are the techniques SolveStep tries in order, DefaultTechniques
+	// if not set
+	Techniques []Technique
+	// SkipHiddenSubsets disables the hidden pair/triple/quad techniques
+	SkipHiddenSubsets bool
+	// OnStep is called for every step taken while solving if set
+	OnStep func(Step)
+	// Stats is filled with statistics about the solving process if set
+	Stats *SolveStats
+	// OnCopy solves a clone and leaves this sudoku untouched, the solved
+	// clone is returned by Solve
+	OnCopy bool
+	// Workers is the number of goroutines SolveParallel searches with,
+	// runtime.NumCPU() if not set
+	Workers int
+
+	// ctx cancels the backtracking search if set
+	ctx context.Context
+}
+
+// techniques returns the ordered techniques to use for solving
+func (opts SolveOptions) techniques() []Technique {
+	techniques := opts.Techniques
+	if techniques == nil {
+		techniques = defaultTechniques
+	}
+	if !opts.SkipHiddenSubsets {
+		return techniques
+	}
+	result := make([]Technique, 0, len(techniques))
+	for _, technique := range techniques {
+		switch technique.Name() {
+		case HiddenPair, HiddenTriple, HiddenQuad:
+			continue
+		}
+		result = append(result, technique)
+	}
+	return result
+}
+
+// SolveStats counts how a sudoku was solved
+type SolveStats struct {
+	// Techniques counts the deductive steps per technique
+	Techniques map[TechniqueName]int
+	// Guesses counts the values tried while brute forcing
+	Guesses int
+	// Searched counts the fields solved by the backend once deduction stalled
+	Searched int
+	// Nodes counts the search tree nodes visited by the backend
+	Nodes int
+	// Backtracks counts the guesses the backend had to revert
+	Backtracks int
+}
+
+func (st *SolveStats) addTechnique(technique TechniqueName) {
+	if st.Techniques == nil {
+		st.Techniques = make(map[TechniqueName]int)
+	}
+	st.Techniques[technique]++
 }
 
-// New returns a new sudoku puzzle
+// New returns a new sudoku puzzle, size must not exceed MaxSize
 func New(size int) *Sudoku {
+	if size > MaxSize {
+		panic(fmt.Sprintf("sudoku size %d exceeds maximum size %d", size, MaxSize))
+	}
 	s := &Sudoku{
 		Size:     size,
 		MaxValue: size * size,
+		history:  &history{},
 	}
 	fieldLength := len(strconv.Itoa(s.MaxValue))
 	s.FieldLength = fieldLength
@@ -81,40 +148,16 @@ func New(size int) *Sudoku {
 func FromFile(filename string) (*Sudoku, error) {
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	return FromReader(strings.NewReader(string(data)))
 }
 
+// FromReader reads a sudoku, one character per field. Whitespace is ignored,
+// any non-digit character marks an empty field. Invalid input is reported
+// as *LengthError, *SizeError, *ValueError or *DuplicateError.
 func FromReader(input io.Reader) (*Sudoku, error) {
-	data, err := ioutil.ReadAll(input)
-	if err != nil {
-		return nil, err
-	}
-	// clean data
-	cleanString := strings.Map(func(r rune) rune {
-		if unicode.IsSpace(r) {
-			return -1
-		}
-		return r
-	}, string(data))
-
-	mathSize := math.Sqrt(math.Sqrt(float64(len(cleanString))))
-	size := int(mathSize)
-	// TODO check size
-
-	initData := make([]int, int(math.Pow(float64(size), 4)))
-	for i, c := range cleanString {
-		initData[i], err = strconv.Atoi(string(c))
-		if err != nil {
-			// this allows for all non-whitespace chars to signal empty fields
-			initData[i] = 0
-		}
-	}
-
-	s := New(size)
-	s.Init(initData)
-	return s, nil
+	return Parse(input, Notation{})
 }
 
 // IsSolved checks if this sudoku is solved
@@ -159,16 +202,41 @@ func (s Sudoku) String() string {
 	return result
 }
 
-// Init inits
+// Init sets the values of the puzzle, all values set are givens
 func (s Sudoku) Init(input []int) Sudoku {
 	for i, val := range input {
 		s.Fields[i].Value = val
+		s.Fields[i].given = val != 0
 	}
 	return s
 }
 
+// Clone returns a deep copy of this sudoku. Values and candidates are copied,
+// groups are rebuilt for the new fields. The history starts empty.
+func (s Sudoku) Clone() *Sudoku {
+	c := New(s.Size)
+	for i, f := range s.Fields {
+		c.Fields[i].Value = f.Value
+		c.Fields[i].NonValues = f.NonValues
+		c.Fields[i].propagated = f.propagated
+		c.Fields[i].given = f.given
+	}
+	return c
+}
+
+// values returns the values of all fields
+func (s Sudoku) values() []int {
+	result := make([]int, len(s.Fields))
+	for i, f := range s.Fields {
+		result[i] = f.Value
+	}
+	return result
+}
+
 // Reason
 func (s Sudoku) Reason() Sudoku {
+	s.history.begin()
+	defer s.history.end()
 	for _, f := range s.Fields {
 		if f.IsSolved() {
 			s.addSolution(f, f.Value)
@@ -179,25 +247,45 @@ func (s Sudoku) Reason() Sudoku {
 
 // GetRow returns all fields of the same row as given field
 func (s Sudoku) GetRow(f *Field) FieldGroup {
-	row := int(math.Floor(float64(f.Index) / float64(s.MaxValue)))
-	return s.rows[row]
+	return s.rows[f.Index/s.MaxValue]
 }
 
 // GetCol returns all fields of the same column as given field
 func (s Sudoku) GetCol(f *Field) FieldGroup {
-	col := int(math.Mod(float64(f.Index), float64(s.MaxValue)))
-	return s.cols[col]
+	return s.cols[f.Index%s.MaxValue]
 }
 
 // GetBlock gets all block Fields for a given field
 func (s Sudoku) GetBlock(f *Field) FieldGroup {
-	row := int(math.Floor(float64(f.Index) / float64(s.MaxValue)))
-	col := int(math.Mod(float64(f.Index), float64(s.MaxValue)))
-	blockIndex := int(math.Floor(float64(row)/float64(s.Size)))*s.Size + int(math.Floor(float64(col)/float64(s.Size)))
+	row := f.Index / s.MaxValue
+	col := f.Index % s.MaxValue
+	blockIndex := (row/s.Size)*s.Size + col/s.Size
 
 	return s.blocks[blockIndex]
 }
 
+// Sees checks if two different fields share a row, col or block
+func (s Sudoku) Sees(a, b *Field) bool {
+	if a == b {
+		return false
+	}
+	rowA, colA := a.Index/s.MaxValue, a.Index%s.MaxValue
+	rowB, colB := b.Index/s.MaxValue, b.Index%s.MaxValue
+	sameBlock := rowA/s.Size == rowB/s.Size && colA/s.Size == colB/s.Size
+	return rowA == rowB || colA == colB || sameBlock
+}
+
+// Peers returns all other fields sharing a row, col or block with the given field
+func (s Sudoku) Peers(f *Field) []*Field {
+	result := make([]*Field, 0, 3*s.MaxValue)
+	for _, other := range s.Fields {
+		if s.Sees(f, other) {
+			result = append(result, other)
+		}
+	}
+	return result
+}
+
 func (s Sudoku) SolvedFieldCount() int {
 	result := 0
 	for _, f := range s.Fields {
@@ -208,12 +296,29 @@ func (s Sudoku) SolvedFieldCount() int {
 	return result
 }
 
-func (s Sudoku) addSolutionByIndex(index, value int) {
-	s.addSolution(s.Fields[index], value)
+func (s Sudoku) addSolutionByIndex(index, value int) []Elimination {
+	return s.addSolution(s.Fields[index], value)
 }
 
-func (s Sudoku) addSolution(f *Field, value int) {
+// addSolution sets the value of a field and denies it for all other fields
+// of the same row, col and block. Returns the newly denied values.
+func (s Sudoku) addSolution(f *Field, value int) []Elimination {
+	s.history.begin()
+	defer s.history.end()
+	s.history.record(change{
+		field:              f.Index,
+		value:              value,
+		previousValue:      f.Value,
+		previousPropagated: f.propagated,
+	})
 	f.Value = value
+	f.propagated = value
+	var eliminations []Elimination
+	deny := func(other *Field) {
+		if other.DenyValue(value) {
+			eliminations = append(eliminations, Elimination{Field: other.Index, Value: value})
+		}
+	}
 
 	rowFields := s.GetRow(f)
 	for _, rf := range rowFields.Fields {
@@ -221,7 +326,7 @@ func (s Sudoku) addSolution(f *Field, value int) {
 			continue
 		}
 		//fmt.Printf("Field %d can't be of value %d (row check)\n", rf.Index, f.Value)
-		rf.DenyValue(f.Value)
+		deny(rf)
 	}
 
 	colFields := s.GetCol(f)
@@ -230,7 +335,7 @@ func (s Sudoku) addSolution(f *Field, value int) {
 			continue
 		}
 		//fmt.Printf("Field %d can't be of value %d (col check)\n", cf.Index, f.Value)
-		cf.DenyValue(f.Value)
+		deny(cf)
 	}
 
 	blockFields := s.GetBlock(f)
@@ -239,136 +344,160 @@ func (s Sudoku) addSolution(f *Field, value int) {
 			continue
 		}
 		//fmt.Printf("Field %d can't be of value %d (block check)\n", bf.Index, f.Value)
-		bf.DenyValue(f.Value)
+		deny(bf)
 	}
+	return eliminations
 }
 
 // IsValidSolution checks a sudoku for validity
 func (s Sudoku) IsValidSolution() bool {
-	var set *IntSet
-
-	for _, row := range s.rows {
-		set = NewIntSet()
-		for _, f := range row.Fields {
+	for _, group := range s.groups() {
+		var set BitSet
+		for _, f := range group.Fields {
 			if f.IsSolved() {
 				set.Add(f.Value)
 			}
 		}
-		if len(set.Values()) < s.MaxValue {
+		if set.Len() < s.MaxValue {
 			return false
 		}
 	}
+	return true
+}
 
-	for _, col := range s.cols {
-		set = NewIntSet()
-		for _, f := range col.Fields {
-			if f.IsSolved() {
-				set.Add(f.Value)
-			}
-		}
-		if len(set.Values()) < s.MaxValue {
-			return false
-		}
+// Solve solves
+func (s Sudoku) Solve(opts SolveOptions) Sudoku {
+	if opts.OnCopy {
+		s = *s.Clone()
 	}
+	s.deduce(opts.context(), opts)
 
-	for _, block := range s.blocks {
-		set = NewIntSet()
-		for _, f := range block.Fields {
-			if f.IsSolved() {
-				set.Add(f.Value)
-			}
+	// Phase 2: Searching
+	if !s.IsSolved() {
+		unsolved := len(s.UnsolvedFields())
+		solved := false
+		switch opts.Backend {
+		case Backtracking:
+			solved = s.SolveBrute(opts)
+		default:
+			solved = s.SolveDancingLinks(opts)
 		}
-		if len(set.Values()) < s.MaxValue {
-			return false
+		if solved && opts.Stats != nil {
+			opts.Stats.Searched += unsolved
 		}
 	}
-
-	return true
+	return s
 }
 
-// Solve solves
-func (s Sudoku) Solve(opts SolveOptions) Sudoku {
-	res := SolvingResult{
-		FoundNew: true,
-	}
-
+// deduce propagates the known values and applies techniques until none
+// finds anything new (Phase 1). It stops early if ctx is done.
+func (s Sudoku) deduce(ctx context.Context, opts SolveOptions) error {
 	// what do we already know?
 	s.Reason()
 
-	// Phase 1: Deduction
-	if !opts.DontDeduce {
-		for !s.IsSolved() && res.FoundNew {
-			res = s.SolveStep(opts)
-			if opts.PrintSteps {
-				fmt.Println(res)
-				fmt.Println(s)
-			}
-		}
+	if opts.DontDeduce {
+		return nil
 	}
-	// Phase 2: Backtracking
-	if !s.IsSolved() {
-		s.SolveBrute(opts)
+	for !s.IsSolved() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		res := s.SolveStep(opts)
+		if !res.FoundNew {
+			break
+		}
+		if opts.Stats != nil {
+			opts.Stats.addTechnique(res.Step.Technique)
+		}
+		if opts.OnStep != nil {
+			opts.OnStep(res.Step)
+		}
 	}
-	return s
+	return nil
 }
 
+// SolvingResult is the outcome of a single solving step
 type SolvingResult struct {
 	FoundNew bool
-	Message  string
+	Step     Step
 }
 
 func (s SolvingResult) String() string {
-	return s.Message
+	return s.Step.String()
 }
 
 // SolveStep solves one step
 func (s Sudoku) SolveStep(opts SolveOptions) SolvingResult {
-	var res SolvingResult
+	s.history.begin()
+	defer s.history.end()
 
 	// loop all solved fields at begin
 	//   create falsity information along all three dimensions
 	for _, f := range s.Fields {
-		if f.IsSolved() {
+		if f.IsSolved() && f.propagated != f.Value {
 			s.addSolution(f, f.Value)
 		}
 	}
 
+	for _, technique := range opts.techniques() {
+		if step := technique.Apply(&s); step != nil {
+			return SolvingResult{
+				FoundNew: true,
+				Step:     *step,
+			}
+		}
+	}
+	return SolvingResult{}
+}
+
+// SolveNakedSingle solves the first field with only one possible value left (one step!)
+func (s Sudoku) SolveNakedSingle() SolvingResult {
 	for _, f := range s.Fields {
 		// not solved
-		if !f.IsSolved() {
-			if f.Solve() {
-				res = SolvingResult{
-					FoundNew: true,
-					Message:  fmt.Sprintf("Field %d could be deduced because there was only one more possible value which is %d", f.Index, f.Value),
-				}
-				return res
+		if !f.IsSolved() && f.Solvable() {
+			value := f.PossibleValues()[0]
+			return SolvingResult{
+				FoundNew: true,
+				Step: Step{
+					Technique:    NakedSingle,
+					Fields:       []int{f.Index},
+					Value:        value,
+					Eliminations: s.addSolution(f, value),
+					Message:      fmt.Sprintf("Field %d could be deduced because there was only one more possible value which is %d", f.Index, value),
+				},
 			}
 		}
 	}
+	return SolvingResult{}
+}
 
+// SolveHiddenSingle solves the first value that is possible in only one
+// field of a row, col or block (one step!)
+func (s Sudoku) SolveHiddenSingle() SolvingResult {
 	// loop all three dimensions
 	//   loop all valid numbers
 	//     loop all fields for checking
 	//       solve and go back to start
-	for _, row := range s.rows {
-		res = row.Solve()
-		if res.FoundNew {
-			return res
-		}
-	}
-	for _, col := range s.cols {
-		res = col.Solve()
-		if res.FoundNew {
-			return res
-		}
-	}
-	for _, block := range s.blocks {
-		res = block.Solve()
+	return s.solveGroups(FieldGroup.Solve)
+}
+
+// solveGroups applies solve to all rows, cols and blocks until it finds something
+func (s Sudoku) solveGroups(solve func(FieldGroup) SolvingResult) SolvingResult {
+	for _, group := range s.groups() {
+		res := solve(group)
 		if res.FoundNew {
 			return res
 		}
 	}
-	return res
+	return SolvingResult{}
+}
+
+// groups returns all rows, cols and blocks
+func (s Sudoku) groups() []FieldGroup {
+	result := make([]FieldGroup, 0, len(s.rows)+len(s.cols)+len(s.blocks))
+	result = append(result, s.rows...)
+	result = append(result, s.cols...)
+	return append(result, s.blocks...)
 }
 
 func (s Sudoku) UnsolvedFields() []*Field {
@@ -400,37 +529,110 @@ func (s Sudoku) CanPut(field *Field, value int) bool {
 	return true
 }
 
-// SolveBrute brute-forces a sudoku
+// SolveBrute brute-forces a sudoku. It always branches on the field with the
+// fewest possible values, denies a guessed value for all related fields and
+// reverts that when backtracking.
 func (s Sudoku) SolveBrute(options SolveOptions) bool {
-	if options.PrintSteps {
-		fmt.Println("I need brute force.")
+	// what do we already know?
+	for _, f := range s.Fields {
+		if f.IsSolved() && f.propagated != f.Value {
+			s.addSolution(f, f.Value)
+		}
 	}
-	// missing fields?
-	fields := s.UnsolvedFields()
 
-	// solved?
-	return s.solveBruteStep(options, fields)
+	return s.solveBruteStep(options)
 }
 
-func (s Sudoku) solveBruteStep(options SolveOptions, fields []*Field) bool {
-	if len(fields) == 0 {
+func (s Sudoku) solveBruteStep(options SolveOptions) bool {
+	if options.Stats != nil {
+		options.Stats.Nodes++
+	}
+
+	if options.cancelled() {
+		return false
+	}
+
+	f, count := s.mostConstrainedField()
+	// solved?
+	if f == nil {
 		return s.IsValidSolution()
 	}
-	f := fields[0]
+	if count == 0 {
+		return false
+	}
 
 	for _, v := range f.PossibleValues() {
-		if options.PrintSteps {
-			fmt.Printf("trying %d at field %d\n", v, f.Index)
-		}
-		if !s.CanPut(f, v) {
-			continue
+		if count > 1 {
+			options.guess(f.Index, v)
 		}
-		f.Value = v
-		if s.solveBruteStep(options, fields[1:]) {
+		checkpoint := s.Checkpoint()
+		s.addSolution(f, v)
+		if s.solveBruteStep(options) {
 			return true
 		}
+		if options.Stats != nil {
+			options.Stats.Backtracks++
+		}
+		s.RestoreCheckpoint(checkpoint)
 	}
-	f.Value = 0
 
 	return false
 }
+
+// mostConstrainedField returns the unsolved field with the fewest possible
+// values (minimum remaining values) and their count, nil if all fields are
+// solved. A count of 0 means the sudoku can't be solved anymore.
+func (s Sudoku) mostConstrainedField() (*Field, int) {
+	var f *Field
+	count := 0
+	for _, field := range s.Fields {
+		if field.IsSolved() {
+			continue
+		}
+		fieldCount := field.Candidates().Len()
+		if f == nil || fieldCount < count {
+			f, count = field, fieldCount
+		}
+		if count == 0 {
+			break
+		}
+	}
+	return f, count
+}
+
+// guess reports a value tried while searching
+func (opts SolveOptions) guess(field, value int) {
+	if opts.Stats != nil {
+		opts.Stats.Guesses++
+	}
+	if opts.OnStep != nil {
+		opts.OnStep(Step{
+			Technique: BruteForce,
+			Fields:    []int{field},
+			Value:     value,
+			Message:   fmt.Sprintf("Trying %d at field %d", value, field),
+		})
+	}
+}
+
+// CountSolutions counts the solutions of this sudoku by exploring the whole
+// search tree. The search stops as soon as limit solutions have been
+// found, a limit < 1 means no limit.
+// The values of the sudoku are left untouched.
+func (s Sudoku) CountSolutions(limit int) int {
+	return newDancingLinks(s).solve(limit)
+}
+
+// CountSolutionsContext counts like CountSolutions, but stops as soon as ctx
+// is done and returns ctx.Err() then
+func (s Sudoku) CountSolutionsContext(ctx context.Context, limit int) (int, error) {
+	d := newDancingLinks(s)
+	d.ctx = ctx
+	count := d.solve(limit)
+	return count, ctx.Err()
+}
+
+// IsUnique checks if this sudoku has exactly one solution
+func (s Sudoku) IsUnique() bool {
+	return s.CountSolutions(2) == 1
+}
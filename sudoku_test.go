@@ -1,6 +1,9 @@
 package sudoku
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
 	"strings"
 	"testing"
 
@@ -29,6 +32,43 @@ func TestFromReader(t *testing.T) {
 	assert.Equal(t, 15, s.SolvedFieldCount())
 }
 
+func TestFromReaderErrors(t *testing.T) {
+	_, err := FromReader(strings.NewReader("123"))
+	assert.Equal(t, &LengthError{Length: 3}, err)
+
+	_, err = FromReader(strings.NewReader(strings.Repeat("-", 36)))
+	assert.Equal(t, &SizeError{LineSize: 6}, err)
+	assert.Equal(t, "invalid sudoku: line size 6 is not a square number", err.Error())
+
+	_, err = FromReader(strings.NewReader(`
+		12 --
+		-- 5-
+
+		-- --
+		-- --
+	`))
+	assert.Equal(t, &ValueError{Row: 1, Col: 2, Value: 5, MaxValue: 4}, err)
+
+	_, err = FromReader(strings.NewReader(`
+		12 --
+		-- 1-
+
+		-- --
+		-2 --
+	`))
+	if assert.IsType(t, &DuplicateError{}, err) {
+		duplicate := err.(*DuplicateError)
+		assert.Equal(t, 2, duplicate.Value)
+		assert.Equal(t, "col 1", duplicate.Group)
+		assert.Equal(t, []int{1, 13}, duplicate.Fields)
+	}
+}
+
+func TestFromFileMissing(t *testing.T) {
+	_, err := FromFile("testfiles/missing.sudoku")
+	assert.NotNil(t, err)
+}
+
 func TestSudokuString(t *testing.T) {
 	s, _ := FromFile("testfiles/small.sudoku")
 
@@ -156,3 +196,209 @@ func TestSolveBruteVeryHard(t *testing.T) {
 	s.Solve(SolveOptions{DontDeduce: true})
 	assert.True(t, s.IsSolved(), "Very hard 3x3 sudoku not solved")
 }
+
+func TestCountSolutions(t *testing.T) {
+	s := New(2)
+	assert.Equal(t, 288, s.CountSolutions(0))
+	assert.Equal(t, 10, s.CountSolutions(10))
+	assert.Equal(t, 0, s.SolvedFieldCount())
+
+	s2, _ := FromFile("testfiles/easy.sudoku")
+	solved := s2.SolvedFieldCount()
+	assert.Equal(t, 1, s2.CountSolutions(0))
+	assert.Equal(t, solved, s2.SolvedFieldCount())
+
+	s3 := New(2).Init([]int{
+		1, 2, 3, 4,
+		3, 4, 1, 2,
+		2, 1, 4, 3,
+		4, 3, 1, 1,
+	})
+	assert.Equal(t, 0, s3.CountSolutions(0))
+}
+
+func TestCountSolutionsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	count, err := New(2).CountSolutionsContext(ctx, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 288, count)
+
+	// an empty 9x9 sudoku has far too many solutions to count
+	cancel()
+	_, err = New(3).CountSolutionsContext(ctx, 0)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestIsUnique(t *testing.T) {
+	s, _ := FromFile("testfiles/simple.sudoku")
+	assert.True(t, s.IsUnique())
+
+	s2, _ := FromReader(strings.NewReader(`
+		12 --
+		-- --
+
+		-- --
+		-- --
+	`))
+	assert.False(t, s2.IsUnique())
+}
+
+func TestSolveOnStep(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	missing := 81 - s.SolvedFieldCount()
+	steps := make([]Step, 0)
+	s.Solve(SolveOptions{OnStep: func(step Step) {
+		steps = append(steps, step)
+	}})
+	assert.True(t, s.IsSolved())
+	assert.Equal(t, missing, len(steps))
+	for _, step := range steps {
+		assert.Contains(t, []TechniqueName{NakedSingle, HiddenSingle}, step.Technique)
+		assert.Len(t, step.Fields, 1)
+		assert.Equal(t, step.Value, s.Fields[step.Fields[0]].Value)
+		assert.NotEmpty(t, step.Message)
+		for _, e := range step.Eliminations {
+			assert.Equal(t, step.Value, e.Value)
+		}
+	}
+
+	s2, _ := FromFile("testfiles/hard.sudoku")
+	guesses := 0
+	s2.Solve(SolveOptions{DontDeduce: true, Backend: Backtracking, OnStep: func(step Step) {
+		assert.Equal(t, BruteForce, step.Technique)
+		guesses++
+	}})
+	assert.True(t, guesses > 0)
+}
+
+func TestSolveStepSkipHiddenSubsets(t *testing.T) {
+	setup := func() *Sudoku {
+		s := New(3)
+		for _, f := range s.rows[0].Fields[1:] {
+			if f.Index != 4 {
+				f.DenyValue(1)
+				f.DenyValue(2)
+			}
+		}
+		return s
+	}
+
+	res := setup().SolveStep(SolveOptions{SkipHiddenSubsets: true})
+	assert.False(t, res.FoundNew)
+
+	res = setup().SolveStep(SolveOptions{})
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, HiddenPair, res.Step.Technique)
+}
+
+func TestSolveDeductionVeryHard(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	guesses := 0
+	s.Solve(SolveOptions{OnStep: func(step Step) {
+		if step.Technique == BruteForce {
+			guesses++
+		}
+	}})
+	assert.True(t, s.IsValidSolution())
+	assert.Equal(t, 0, guesses)
+}
+
+func TestClone(t *testing.T) {
+	s, _ := FromFile("testfiles/easy.sudoku")
+	s.Reason()
+	c := s.Clone()
+	assert.Equal(t, s.String(), c.String())
+	for i, f := range c.Fields {
+		assert.Equal(t, s.Fields[i].NonValues, f.NonValues)
+		assert.False(t, f == s.Fields[i])
+		assert.True(t, f.sudoku == c)
+	}
+	for _, group := range c.groups() {
+		for _, f := range group.Fields {
+			assert.True(t, f == c.Fields[f.Index])
+		}
+	}
+
+	c.Solve(SolveOptions{})
+	assert.True(t, c.IsValidSolution())
+	assert.Equal(t, 32, s.SolvedFieldCount())
+}
+
+func TestSolveOnCopy(t *testing.T) {
+	s, _ := FromFile("testfiles/hard.sudoku")
+	givens := s.String()
+	solution := s.Solve(SolveOptions{OnCopy: true})
+	assert.True(t, solution.IsValidSolution())
+	assert.Equal(t, givens, s.String())
+}
+
+func TestSolveBruteStats(t *testing.T) {
+	s, _ := FromFile("testfiles/very-hard.sudoku")
+	stats := &SolveStats{}
+	assert.True(t, s.SolveBrute(SolveOptions{Stats: stats}))
+	assert.True(t, s.IsValidSolution())
+	assert.True(t, stats.Guesses > 0)
+	assert.True(t, stats.Backtracks > 0)
+	assert.True(t, stats.Nodes > stats.Guesses)
+
+	dlxStats := &SolveStats{}
+	s2, _ := FromFile("testfiles/very-hard.sudoku")
+	s2.Solve(SolveOptions{DontDeduce: true, Stats: dlxStats})
+	assert.True(t, dlxStats.Nodes > 0)
+	assert.Equal(t, s.String(), s2.String())
+}
+
+func TestSolveBruteUnsolvable(t *testing.T) {
+	s, _ := FromReader(strings.NewReader(`
+		12 --
+		-- 3-
+
+		-- --
+		-- --
+	`))
+	s.Reason()
+	before := make([]BitSet, len(s.Fields))
+	for i, f := range s.Fields {
+		before[i] = f.NonValues
+	}
+
+	assert.False(t, s.SolveBrute(SolveOptions{}))
+	assert.Equal(t, 3, s.SolvedFieldCount())
+	for i, f := range s.Fields {
+		assert.Equal(t, before[i], f.NonValues, "field %d", i)
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	for _, name := range []string{"simple", "easy", "hard", "very-hard"} {
+		b.Run(name, func(b *testing.B) {
+			data, err := ioutil.ReadFile("testfiles/" + name + ".sudoku")
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s, _ := FromReader(bytes.NewReader(data))
+				s.Solve(SolveOptions{})
+			}
+		})
+	}
+}
+
+func BenchmarkSolveBrute(b *testing.B) {
+	for _, backend := range []Backend{DancingLinks, Backtracking} {
+		for _, name := range []string{"easy", "hard"} {
+			b.Run(backend.String()+"/"+name, func(b *testing.B) {
+				data, err := ioutil.ReadFile("testfiles/" + name + ".sudoku")
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					s, _ := FromReader(bytes.NewReader(data))
+					s.Solve(SolveOptions{DontDeduce: true, Backend: backend})
+				}
+			})
+		}
+	}
+}
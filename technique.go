@@ -0,0 +1,130 @@
+package sudoku
+
+// TechniqueName identifies a solving technique
+type TechniqueName string
+
+const (
+	// NakedSingle is a field with only one possible value left
+	NakedSingle TechniqueName = "naked single"
+	// HiddenSingle is a value with only one possible field left in a row, col or block
+	HiddenSingle TechniqueName = "hidden single"
+	// PointingPair is a value confined to one row or col inside a block
+	PointingPair TechniqueName = "pointing pair"
+	// BoxLineReduction is a value confined to one block inside a row or col
+	BoxLineReduction TechniqueName = "box/line reduction"
+	// NakedPair are two fields of a group sharing the same two possible values
+	NakedPair TechniqueName = "naked pair"
+	// NakedTriple are three fields of a group sharing three possible values
+	NakedTriple TechniqueName = "naked triple"
+	// NakedQuad are four fields of a group sharing four possible values
+	NakedQuad TechniqueName = "naked quad"
+	// HiddenPair are two values that can only be in the same two fields of a group
+	HiddenPair TechniqueName = "hidden pair"
+	// HiddenTriple are three values that can only be in the same three fields of a group
+	HiddenTriple TechniqueName = "hidden triple"
+	// HiddenQuad are four values that can only be in the same four fields of a group
+	HiddenQuad TechniqueName = "hidden quad"
+	// XWing is a value confined to the same two cols in two rows (or vice versa)
+	XWing TechniqueName = "x-wing"
+	// Swordfish is a value confined to the same three cols in three rows (or vice versa)
+	Swordfish TechniqueName = "swordfish"
+	// Jellyfish is a value confined to the same four cols in four rows (or vice versa)
+	Jellyfish TechniqueName = "jellyfish"
+	// SimpleColoring is a chain of conjugate pairs of a value colored alternately
+	SimpleColoring TechniqueName = "simple coloring"
+	// XYWing is a pivot field {x,y} seeing pincer fields {x,z} and {y,z}
+	XYWing TechniqueName = "xy-wing"
+	// XYZWing is a pivot field {x,y,z} seeing pincer fields {x,z} and {y,z}
+	XYZWing TechniqueName = "xyz-wing"
+	// BruteForce is guessing a value while backtracking
+	BruteForce TechniqueName = "brute force"
+)
+
+// Technique is a solving technique SolveStep can apply
+type Technique interface {
+	// Name identifies the technique in steps and statistics
+	Name() TechniqueName
+	// Weight rates how hard it is to apply the technique once, from 1 for a
+	// hidden single to 15 for a jellyfish. Guessing is rated 20.
+	Weight() int
+	// Apply applies the technique once and returns the step taken, nil if
+	// it is not applicable
+	Apply(s *Sudoku) *Step
+}
+
+// NewTechnique creates a Technique from a function solving one step
+func NewTechnique(name TechniqueName, weight int, solve func(s *Sudoku) SolvingResult) Technique {
+	return technique{
+		name:   name,
+		weight: weight,
+		solve:  solve,
+	}
+}
+
+type technique struct {
+	name   TechniqueName
+	weight int
+	solve  func(s *Sudoku) SolvingResult
+}
+
+func (t technique) Name() TechniqueName {
+	return t.name
+}
+
+func (t technique) Weight() int {
+	return t.weight
+}
+
+func (t technique) Apply(s *Sudoku) *Step {
+	res := t.solve(s)
+	if !res.FoundNew {
+		return nil
+	}
+	return &res.Step
+}
+
+// defaultTechniques is shared by all solves without custom techniques, the
+// built-in techniques are stateless
+var defaultTechniques = DefaultTechniques()
+
+// DefaultTechniques returns all built-in techniques, easier ones first
+func DefaultTechniques() []Technique {
+	nakedSubset := func(n int) func(s *Sudoku) SolvingResult {
+		return func(s *Sudoku) SolvingResult {
+			return s.solveGroups(func(group FieldGroup) SolvingResult {
+				return group.SolveNakedSubset(n)
+			})
+		}
+	}
+	hiddenSubset := func(n int) func(s *Sudoku) SolvingResult {
+		return func(s *Sudoku) SolvingResult {
+			return s.solveGroups(func(group FieldGroup) SolvingResult {
+				return group.SolveHiddenSubset(n)
+			})
+		}
+	}
+	fish := func(n int) func(s *Sudoku) SolvingResult {
+		return func(s *Sudoku) SolvingResult {
+			return s.SolveFish(n)
+		}
+	}
+
+	return []Technique{
+		NewTechnique(NakedSingle, 2, (*Sudoku).SolveNakedSingle),
+		NewTechnique(HiddenSingle, 1, (*Sudoku).SolveHiddenSingle),
+		NewTechnique(PointingPair, 3, (*Sudoku).SolvePointing),
+		NewTechnique(BoxLineReduction, 3, (*Sudoku).SolveBoxLineReduction),
+		NewTechnique(NakedPair, 4, nakedSubset(2)),
+		NewTechnique(HiddenPair, 5, hiddenSubset(2)),
+		NewTechnique(NakedTriple, 6, nakedSubset(3)),
+		NewTechnique(HiddenTriple, 7, hiddenSubset(3)),
+		NewTechnique(NakedQuad, 8, nakedSubset(4)),
+		NewTechnique(HiddenQuad, 9, hiddenSubset(4)),
+		NewTechnique(XWing, 10, fish(2)),
+		NewTechnique(SimpleColoring, 11, (*Sudoku).SolveSimpleColoring),
+		NewTechnique(XYWing, 12, (*Sudoku).SolveXYWing),
+		NewTechnique(Swordfish, 13, fish(3)),
+		NewTechnique(XYZWing, 14, (*Sudoku).SolveXYZWing),
+		NewTechnique(Jellyfish, 15, fish(4)),
+	}
+}
@@ -0,0 +1,66 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultTechniques(t *testing.T) {
+	names := make(map[TechniqueName]bool)
+	for _, technique := range DefaultTechniques() {
+		assert.False(t, names[technique.Name()], "duplicate technique %s", technique.Name())
+		names[technique.Name()] = true
+		assert.True(t, technique.Weight() > 0)
+		assert.True(t, technique.Weight() < bruteForceWeight)
+	}
+}
+
+func TestSolveStepTechniques(t *testing.T) {
+	calls := 0
+	never := NewTechnique("never", 1, func(s *Sudoku) SolvingResult {
+		calls++
+		return SolvingResult{}
+	})
+
+	s, _ := FromFile("testfiles/easy.sudoku")
+	res := s.SolveStep(SolveOptions{Techniques: []Technique{never}})
+	assert.False(t, res.FoundNew)
+	assert.Equal(t, 1, calls)
+
+	res = s.SolveStep(SolveOptions{Techniques: []Technique{never, DefaultTechniques()[1]}})
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, HiddenSingle, res.Step.Technique)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSolveCustomTechnique(t *testing.T) {
+	// a technique placing a value of the known solution
+	cheat := NewTechnique("cheat", 3, func(s *Sudoku) SolvingResult {
+		f := s.Fields[2]
+		if f.IsSolved() {
+			return SolvingResult{}
+		}
+		return SolvingResult{
+			FoundNew: true,
+			Step: Step{
+				Technique:    "cheat",
+				Fields:       []int{2},
+				Value:        7,
+				Eliminations: s.addSolution(f, 7),
+			},
+		}
+	})
+
+	s, _ := FromFile("testfiles/easy.sudoku")
+	stats := &SolveStats{}
+	s.Solve(SolveOptions{
+		Techniques: append([]Technique{cheat}, DefaultTechniques()...),
+		Stats:      stats,
+	})
+	assert.True(t, s.IsValidSolution())
+	assert.Equal(t, 1, stats.Techniques["cheat"])
+
+	grading := s.GradeWith(SolveOptions{Techniques: []Technique{cheat}})
+	assert.Equal(t, 0, grading.Techniques["cheat"], "already solved")
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jojomi/sudoku"
+	"github.com/spf13/cobra"
+)
+
+var (
+	batchWorkers     int
+	batchBackend     string
+	batchSummaryOnly bool
+)
+
+// batch result kinds
+const (
+	batchSolved = iota
+	batchUnsolvable
+	batchMultiple
+	batchInvalid
+)
+
+// batchResult is the outcome of solving one puzzle of a batch
+type batchResult struct {
+	kind     int
+	solution string
+	duration time.Duration
+	stats    sudoku.SolveStats
+}
+
+// newBatchCmd returns the batch command
+func newBatchCmd() *cobra.Command {
+	var batchCmd = &cobra.Command{
+		Use:   "batch [filename]",
+		Short: "solve many puzzles, one per line, read from a file or stdin",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   cmdBatch,
+	}
+	batchCmd.Flags().IntVarP(&batchWorkers, "workers", "w", runtime.NumCPU(), "number of puzzles solved in parallel")
+	batchCmd.Flags().StringVarP(&batchBackend, "backend", "b", "dlx", "backend used once deduction stalls (dlx, backtracking)")
+	batchCmd.Flags().BoolVarP(&batchSummaryOnly, "summary-only", "s", false, "don't print solutions, only the summary")
+	return batchCmd
+}
+
+func cmdBatch(cmd *cobra.Command, args []string) {
+	input := io.Reader(os.Stdin)
+	if len(args) > 0 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		input = file
+	}
+	if batchWorkers < 1 {
+		log.Fatalf("need at least 1 worker, got %d", batchWorkers)
+	}
+	backend, err := sudoku.ParseBackend(batchBackend)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	lines, err := sudoku.ReadLines(input)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	start := time.Now()
+	results := make([]batchResult, len(lines))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < batchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				results[index] = solveBatchLine(lines[index], backend)
+			}
+		}()
+	}
+	for index := range lines {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	if !batchSummaryOnly {
+		output := bufio.NewWriter(os.Stdout)
+		for i, result := range results {
+			if result.kind == batchSolved || result.kind == batchMultiple {
+				fmt.Fprintln(output, result.solution)
+			} else {
+				fmt.Fprintln(output, lines[i])
+			}
+		}
+		output.Flush()
+	}
+	printBatchSummary(os.Stderr, results, elapsed)
+}
+
+// solveBatchLine solves a single puzzle written as line
+func solveBatchLine(line string, backend sudoku.Backend) batchResult {
+	s, err := sudoku.Parse(strings.NewReader(line), sudoku.Notation{})
+	if err != nil {
+		return batchResult{kind: batchInvalid}
+	}
+
+	result := batchResult{kind: batchSolved}
+	switch s.CountSolutions(2) {
+	case 0:
+		result.kind = batchUnsolvable
+	case 2:
+		result.kind = batchMultiple
+	}
+	if result.kind != batchUnsolvable {
+		start := time.Now()
+		s.Solve(sudoku.SolveOptions{Backend: backend, Stats: &result.stats})
+		result.duration = time.Since(start)
+	}
+
+	var b strings.Builder
+	s.WriteFormat(&b, sudoku.FormatLine, sudoku.Notation{})
+	result.solution = strings.TrimSpace(b.String())
+	return result
+}
+
+// printBatchSummary writes counts, timing and technique usage of a batch
+func printBatchSummary(w io.Writer, results []batchResult, elapsed time.Duration) {
+	counts := make(map[int]int)
+	techniques := make(map[sudoku.TechniqueName]int)
+	guesses := 0
+	var total, min, max time.Duration
+	timed := 0
+	for _, result := range results {
+		counts[result.kind]++
+		// only solved puzzles are timed
+		if result.kind == batchInvalid || result.kind == batchUnsolvable {
+			continue
+		}
+		for technique, count := range result.stats.Techniques {
+			techniques[technique] += count
+		}
+		guesses += result.stats.Guesses
+		if timed == 0 || result.duration < min {
+			min = result.duration
+		}
+		if result.duration > max {
+			max = result.duration
+		}
+		total += result.duration
+		timed++
+	}
+
+	fmt.Fprintf(w, "puzzles: %d in %s (%.1f/s)\n", len(results), elapsed, float64(len(results))/elapsed.Seconds())
+	fmt.Fprintf(w, "solved: %d, unsolvable: %d, multiple solutions: %d, invalid: %d\n",
+		counts[batchSolved], counts[batchUnsolvable], counts[batchMultiple], counts[batchInvalid])
+	if timed > 0 {
+		fmt.Fprintf(w, "time per puzzle: min %s, avg %s, max %s\n", min, total/time.Duration(timed), max)
+	}
+
+	names := make([]string, 0, len(techniques))
+	for technique := range techniques {
+		names = append(names, string(technique))
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "techniques:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s: %d\n", name, techniques[sudoku.TechniqueName(name)])
+	}
+	fmt.Fprintf(w, "  guesses: %d\n", guesses)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/jojomi/sudoku"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkAlphabet    string
+	checkInputFormat string
+)
+
+// newCheckCmd returns the check command
+func newCheckCmd() *cobra.Command {
+	var checkCmd = &cobra.Command{
+		Use:   "check puzzle grid",
+		Short: "report the mistakes in a partially filled grid, exits with 1 if it can't be solved anymore",
+		Args:  cobra.ExactArgs(2),
+		Run:   cmdCheck,
+	}
+	checkCmd.Flags().StringVarP(&checkAlphabet, "alphabet", "a", "", "characters of the values (digits, hex, hex1, letters)")
+	checkCmd.Flags().StringVarP(&checkInputFormat, "input-format", "i", "auto", "format of the input files (auto, grid, line, sdm, sadman, ss, json)")
+	return checkCmd
+}
+
+func cmdCheck(cmd *cobra.Command, args []string) {
+	notation := sudoku.Notation{}
+	if checkAlphabet != "" {
+		alphabet, err := sudoku.ParseAlphabet(checkAlphabet)
+		if err != nil {
+			log.Fatal(err)
+		}
+		notation.Alphabet = alphabet
+	}
+	format, err := sudoku.ParseFormat(checkInputFormat)
+	if err != nil {
+		log.Fatal(err)
+	}
+	puzzle, err := readSudoku(args[0], format, notation, sudoku.Read)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// conflicts of the grid are reported by Check
+	grid, err := readSudoku(args[1], format, notation, sudoku.ReadUnchecked)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := sudoku.Check(puzzle, grid)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, index := range result.Incorrect {
+		fmt.Printf("incorrect: row %d, col %d\n", index/grid.MaxValue, index%grid.MaxValue)
+	}
+	for _, index := range result.Conflicts {
+		fmt.Printf("conflict: row %d, col %d\n", index/grid.MaxValue, index%grid.MaxValue)
+	}
+	if !result.Solvable {
+		fmt.Println("the grid can't be solved anymore")
+		os.Exit(1)
+	}
+	fmt.Printf("no mistakes, %d fields left\n", len(grid.UnsolvedFields()))
+}
+
+// readSudoku reads the first sudoku of a file with the given read function
+func readSudoku(filename string, format sudoku.Format, notation sudoku.Notation, read func(io.Reader, sudoku.Format, sudoku.Notation) (*sudoku.Sudoku, error)) (*sudoku.Sudoku, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return read(file, format, notation)
+}
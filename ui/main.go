@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/jojomi/sudoku"
 	"github.com/spf13/cobra"
@@ -11,14 +13,56 @@ import (
 
 var (
 	solveOptionsPrintSteps bool
+	solveOptionsBackend    string
+	solveOptionsStats      bool
+	solveOptionsWorkers    int
+	solveTimeout           time.Duration
+	notationAlphabet       string
+	notationTokens         bool
+	inputFormat            string
+	outputFormat           string
+	countSolutions         bool
+	countLimit             int
+	gradeSudoku            bool
+	generateSize           int
+	generateSeed           int64
+	generateClues          int
+	generateSymmetry       string
 )
 
 func main() {
 	var rootCmd = &cobra.Command{
-		Use: "sudoku filename",
-		Run: cmdSolve,
+		Use:  "sudoku filename",
+		Args: cobra.ArbitraryArgs,
+		Run:  cmdSolve,
 	}
-	rootCmd.PersistentFlags().BoolVarP(&solveOptionsPrintSteps, "print-steps", "p", false, "print steps while solving sudoku")
+	rootCmd.Flags().BoolVarP(&solveOptionsPrintSteps, "print-steps", "p", false, "print steps while solving sudoku")
+	rootCmd.Flags().StringVarP(&solveOptionsBackend, "backend", "b", "dlx", "backend used once deduction stalls (dlx, backtracking)")
+	rootCmd.Flags().BoolVar(&solveOptionsStats, "stats", false, "print search statistics (nodes, guesses, backtracks) after solving")
+	rootCmd.Flags().IntVarP(&solveOptionsWorkers, "workers", "w", 0, "search in parallel with this many goroutines (0 = single-threaded)")
+	rootCmd.Flags().DurationVar(&solveTimeout, "timeout", 0, "give up solving after this duration (0 = no timeout)")
+	rootCmd.Flags().StringVarP(&notationAlphabet, "alphabet", "a", "", "characters of the values (digits, hex, hex1, letters)")
+	rootCmd.Flags().BoolVarP(&notationTokens, "tokens", "t", false, "read and print values as whitespace separated numbers")
+	rootCmd.Flags().StringVarP(&inputFormat, "input-format", "i", "auto", "format of the input file (auto, grid, line, sdm, sadman, ss, json)")
+	rootCmd.Flags().StringVarP(&outputFormat, "output-format", "o", "", "print only the solution in this format (grid, line, sdm, sadman, ss, json)")
+	rootCmd.Flags().BoolVarP(&countSolutions, "count", "c", false, "count solutions instead of solving sudoku")
+	rootCmd.Flags().IntVar(&countLimit, "count-limit", 0, "stop counting after this many solutions (0 = no limit)")
+	rootCmd.Flags().BoolVarP(&gradeSudoku, "grade", "g", false, "grade the difficulty of the sudoku instead of solving it")
+
+	var generateCmd = &cobra.Command{
+		Use:   "generate",
+		Short: "generate a new sudoku with a unique solution",
+		Run:   cmdGenerate,
+	}
+	generateCmd.Flags().IntVarP(&generateSize, "size", "s", 3, "box size of the sudoku (3 for a 9x9 grid)")
+	generateCmd.Flags().Int64Var(&generateSeed, "seed", time.Now().UnixNano(), "random seed")
+	generateCmd.Flags().IntVar(&generateClues, "clues", 0, "targeted number of givens (0 = as few as possible)")
+	generateCmd.Flags().StringVar(&generateSymmetry, "symmetry", "none", "symmetry of the givens (none, rotational, mirror)")
+	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(newBatchCmd())
+	rootCmd.AddCommand(newServeCmd())
+	rootCmd.AddCommand(newPlayCmd())
+	rootCmd.AddCommand(newCheckCmd())
 
 	rootCmd.Execute()
 }
@@ -29,18 +73,159 @@ func cmdSolve(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	s, err := sudoku.FromFile(args[0])
+	notation, err := parseNotation()
+	if err != nil {
+		log.Fatal(err)
+	}
+	format, err := sudoku.ParseFormat(inputFormat)
+	if err != nil {
+		log.Fatal(err)
+	}
+	file, err := os.Open(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	sudokus, err := sudoku.ReadAll(file, format, notation)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	for _, s := range sudokus {
+		solve(s, notation)
+	}
+}
+
+// solve handles a single sudoku read from input
+func solve(s *sudoku.Sudoku, notation sudoku.Notation) {
+	if countSolutions {
+		fmt.Println("parsed sudoku from input:")
+		fmt.Println(s)
+		count := s.CountSolutions(countLimit)
+		fmt.Printf("solutions: %d\n", count)
+		if count == 1 {
+			fmt.Println("the solution is unique")
+		} else if count > 1 {
+			fmt.Println("the solution is not unique")
+		}
+		return
+	}
+
+	if gradeSudoku {
+		fmt.Println("parsed sudoku from input:")
+		fmt.Println(s)
+		grading := s.Grade()
+		fmt.Printf("difficulty: %s\n", grading)
+		for technique, count := range grading.Techniques {
+			fmt.Printf("  %s: %d\n", technique, count)
+		}
+		if grading.Guesses > 0 {
+			fmt.Printf("  guesses: %d\n", grading.Guesses)
+		}
+		return
+	}
+
+	backend, err := sudoku.ParseBackend(solveOptionsBackend)
+	if err != nil {
+		log.Fatal(err)
+	}
 	opts := sudoku.SolveOptions{
-		PrintSteps: solveOptionsPrintSteps,
+		Backend: backend,
+		Workers: solveOptionsWorkers,
+	}
+	if solveOptionsPrintSteps {
+		opts.OnStep = printStep(s)
+	}
+	if solveOptionsStats {
+		opts.Stats = &sudoku.SolveStats{}
 	}
 
-	fmt.Println("parsed sudoku from input:")
-	fmt.Println(s)
-	s.Solve(opts)
+	var format sudoku.Format
+	if outputFormat != "" {
+		format, err = sudoku.ParseFormat(outputFormat)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		fmt.Println("parsed sudoku from input:")
+		fmt.Println(s)
+	}
+	if solveOptionsWorkers > 0 || solveTimeout > 0 {
+		ctx := context.Background()
+		if solveTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, solveTimeout)
+			defer cancel()
+		}
+		if err := s.SolveContext(ctx, opts); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		s.Solve(opts)
+	}
+	if outputFormat != "" {
+		if err := s.WriteFormat(os.Stdout, format, notation); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	fmt.Println("solution:")
+	if notation == (sudoku.Notation{}) {
+		fmt.Println(s)
+	} else {
+		output, err := s.Print(notation)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(output)
+	}
+	if opts.Stats != nil {
+		fmt.Printf("nodes: %d, guesses: %d, backtracks: %d\n", opts.Stats.Nodes, opts.Stats.Guesses, opts.Stats.Backtracks)
+	}
+}
+
+// parseNotation returns the notation selected by flags
+func parseNotation() (sudoku.Notation, error) {
+	notation := sudoku.Notation{Tokens: notationTokens}
+	if notationAlphabet == "" {
+		return notation, nil
+	}
+	alphabet, err := sudoku.ParseAlphabet(notationAlphabet)
+	notation.Alphabet = alphabet
+	return notation, err
+}
+
+func cmdGenerate(cmd *cobra.Command, args []string) {
+	if generateSize < 1 || generateSize > sudoku.MaxSize {
+		log.Fatalf("size %d is out of range 1-%d", generateSize, sudoku.MaxSize)
+	}
+	symmetry, err := sudoku.ParseSymmetry(generateSymmetry)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s := sudoku.Generate(generateSize, sudoku.GenerateOptions{
+		Seed:     generateSeed,
+		Clues:    generateClues,
+		Symmetry: symmetry,
+	})
 	fmt.Println(s)
+	fmt.Printf("givens: %d, seed: %d\n", s.SolvedFieldCount(), generateSeed)
+}
+
+// printStep returns a step handler rendering steps to stdout
+func printStep(s *sudoku.Sudoku) func(sudoku.Step) {
+	bruteForce := false
+	return func(step sudoku.Step) {
+		if step.Technique != sudoku.BruteForce {
+			fmt.Println(step)
+			fmt.Println(s)
+			return
+		}
+		if !bruteForce {
+			fmt.Println("I need brute force.")
+			bruteForce = true
+		}
+		fmt.Println(step)
+	}
 }
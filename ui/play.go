@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/jojomi/sudoku"
+	"github.com/spf13/cobra"
+)
+
+var playAlphabet string
+
+// terminal control sequences
+const (
+	clearScreen = "\033[H\033[2J"
+	styleReset  = "\033[0m"
+	styleCursor = "\033[7m"
+	styleGiven  = "\033[1m"
+	styleError  = "\033[31m"
+	styleHint   = "\033[32m"
+)
+
+// newPlayCmd returns the play command
+func newPlayCmd() *cobra.Command {
+	var playCmd = &cobra.Command{
+		Use:   "play filename",
+		Short: "solve a sudoku interactively in the terminal",
+		Args:  cobra.ExactArgs(1),
+		Run:   cmdPlay,
+	}
+	playCmd.Flags().StringVarP(&playAlphabet, "alphabet", "a", "", "characters of the values (digits, hex, hex1, letters)")
+	return playCmd
+}
+
+func cmdPlay(cmd *cobra.Command, args []string) {
+	notation := sudoku.Notation{}
+	if playAlphabet != "" {
+		alphabet, err := sudoku.ParseAlphabet(playAlphabet)
+		if err != nil {
+			log.Fatal(err)
+		}
+		notation.Alphabet = alphabet
+	}
+	file, err := os.Open(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	s, err := sudoku.Read(file, sudoku.FormatAuto, notation)
+	file.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p, err := newPlayer(s, notation.Alphabet)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// without a terminal keys are read line by line
+	if restore, err := rawMode(); err == nil {
+		defer restore()
+	}
+	p.run(os.Stdin, os.Stdout)
+}
+
+// rawMode switches the terminal to read single key presses, the returned
+// function restores the previous mode
+func rawMode() (func(), error) {
+	state, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return func() {
+		stty(strings.TrimSpace(state))
+	}, nil
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	output, err := cmd.Output()
+	return string(output), err
+}
+
+// player is the state of an interactive game
+type player struct {
+	s        *sudoku.Sudoku
+	alphabet sudoku.Alphabet
+	cursor   int
+	pencil   bool
+	// marks are the pencil marks of every field
+	marks []sudoku.BitSet
+	hint  []int
+	// hintLevel is raised by repeated hint requests
+	hintLevel sudoku.HintLevel
+	// eliminations are the candidates removed by the hints shown so far
+	eliminations []sudoku.Elimination
+	message      string
+}
+
+// newPlayer returns a game of the sudoku, without an alphabet the first
+// built-in one long enough for the sudoku is used
+func newPlayer(s *sudoku.Sudoku, alphabet sudoku.Alphabet) (*player, error) {
+	if alphabet == "" {
+		var err error
+		alphabet, err = sudoku.AlphabetFor(s.MaxValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(alphabet) < s.MaxValue {
+		return nil, fmt.Errorf("alphabet %q has too few characters for values up to %d", alphabet, s.MaxValue)
+	}
+	return &player{
+		s:        s,
+		alphabet: alphabet,
+		marks:    make([]sudoku.BitSet, len(s.Fields)),
+	}, nil
+}
+
+// run handles key presses until the player quits or input ends
+func (p *player) run(input io.Reader, output io.Writer) {
+	keys := bufio.NewReader(input)
+	p.render(output)
+	for {
+		key, err := keys.ReadByte()
+		if err != nil {
+			return
+		}
+		// arrow keys are sent as escape sequences
+		if key == 27 {
+			if next, _ := keys.ReadByte(); next == '[' {
+				arrow, _ := keys.ReadByte()
+				key = map[byte]byte{'A': 'k', 'B': 'j', 'C': 'l', 'D': 'h'}[arrow]
+			}
+		}
+		if key == '\r' || key == '\n' {
+			continue
+		}
+		if !p.handleKey(key) {
+			fmt.Fprint(output, "\r\n")
+			return
+		}
+		p.render(output)
+	}
+}
+
+// handleKey applies a key press, returns false to quit
+func (p *player) handleKey(key byte) bool {
+	p.message = ""
+	p.hint = nil
+	if key != '?' {
+		p.hintLevel = sudoku.HintLocation
+	}
+	switch key {
+	case 'q', 3:
+		return false
+	case 'h':
+		p.move(0, -1)
+	case 'j':
+		p.move(1, 0)
+	case 'k':
+		p.move(-1, 0)
+	case 'l':
+		p.move(0, 1)
+	case 'p':
+		p.pencil = !p.pencil
+	case '?':
+		p.showHint()
+	case ' ', '.', 127, 8:
+		p.set(0)
+	default:
+		value, ok := p.alphabet.Value(rune(key))
+		ok = ok && value <= p.s.MaxValue
+		// 0 clears unless it is a value of the alphabet
+		if key == '0' && !ok {
+			p.set(0)
+			break
+		}
+		if !ok {
+			p.message = fmt.Sprintf("unknown key %q", key)
+			break
+		}
+		if p.pencil {
+			p.toggleMark(value)
+		} else {
+			p.set(value)
+		}
+	}
+	return true
+}
+
+func (p *player) move(rows, cols int) {
+	lineSize := p.s.MaxValue
+	row := (p.cursor/lineSize + rows + lineSize) % lineSize
+	col := (p.cursor%lineSize + cols + lineSize) % lineSize
+	p.cursor = row*lineSize + col
+}
+
+// set enters a value at the cursor, 0 clears the field
+func (p *player) set(value int) {
+	f := p.s.Fields[p.cursor]
+	if f.IsGiven() {
+		p.message = "givens can't be changed"
+		return
+	}
+	// eliminations of earlier hints may build on the value replaced, added
+	// values keep them valid
+	if f.Value != 0 && f.Value != value {
+		p.eliminations = nil
+	}
+	f.Value = value
+	if p.s.IsSolved() && len(p.conflicts()) == 0 {
+		p.message = "solved, congratulations!"
+	}
+}
+
+func (p *player) toggleMark(value int) {
+	marks := &p.marks[p.cursor]
+	if !marks.Add(value) {
+		marks.Remove(value)
+	}
+}
+
+// conflicts returns the fields whose value can't be put there because a
+// related field has the same value
+func (p *player) conflicts() map[int]bool {
+	result := make(map[int]bool)
+	for _, index := range p.s.Conflicts() {
+		result[index] = true
+	}
+	return result
+}
+
+// showHint finds the next step for the values entered so far, every further
+// request reveals more of it
+func (p *player) showHint() {
+	if len(p.conflicts()) > 0 {
+		p.message = "fix the conflicts first"
+		return
+	}
+	values := make([]int, len(p.s.Fields))
+	for i, f := range p.s.Fields {
+		values[i] = f.Value
+	}
+	current := sudoku.New(p.s.Size).Init(values)
+	for _, e := range p.eliminations {
+		current.Fields[e.Field].DenyValue(e.Value)
+	}
+	hint, found := current.NextHint(sudoku.SolveOptions{})
+	if !found {
+		p.message = "no hint found"
+		return
+	}
+	p.message = hint.Message(p.hintLevel)
+	if p.hintLevel == sudoku.HintAnswer {
+		// later hints build on the candidates removed by this one
+		p.eliminations = append(p.eliminations, hint.Step.Eliminations...)
+		p.hint = hint.Step.Fields
+		if len(p.hint) > 0 {
+			p.cursor = p.hint[0]
+		}
+		return
+	}
+	p.hintLevel++
+}
+
+// render draws the grid with the borders of Sudoku.String and a status
+func (p *player) render(w io.Writer) {
+	s := p.s
+	conflicts := p.conflicts()
+	border := "+" + strings.Repeat(strings.Repeat("-", s.Size)+"+", s.Size) + "\r\n"
+
+	var b strings.Builder
+	b.WriteString(clearScreen)
+	b.WriteString(border)
+	for row := 0; row < s.MaxValue; row++ {
+		b.WriteString("|")
+		for col := 0; col < s.MaxValue; col++ {
+			index := row*s.MaxValue + col
+			f := s.Fields[index]
+			symbol := "."
+			if f.IsSolved() {
+				symbol = string(p.alphabet.Symbol(f.Value))
+			}
+			style := ""
+			switch {
+			case conflicts[index]:
+				style = styleError
+			case containsIndex(p.hint, index):
+				style = styleHint
+			case f.IsGiven():
+				style = styleGiven
+			}
+			if index == p.cursor {
+				style += styleCursor
+			}
+			if style != "" {
+				symbol = style + symbol + styleReset
+			}
+			b.WriteString(symbol)
+			if col%s.Size == s.Size-1 {
+				b.WriteString("|")
+			}
+		}
+		b.WriteString("\r\n")
+		if row%s.Size == s.Size-1 {
+			b.WriteString(border)
+		}
+	}
+
+	mode := "value"
+	if p.pencil {
+		mode = "pencil"
+	}
+	marks := make([]string, 0)
+	for _, value := range p.marks[p.cursor].Values() {
+		marks = append(marks, string(p.alphabet.Symbol(value)))
+	}
+	fmt.Fprintf(&b, "row %d, col %d, %s mode, marks: %s\r\n", p.cursor/s.MaxValue, p.cursor%s.MaxValue, mode, strings.Join(marks, " "))
+	fmt.Fprintf(&b, "%s\r\n", p.message)
+	b.WriteString("arrows/hjkl move, values set (letters in upper case), space (or 0 if not a value) clears, p toggles pencil marks, ? hint (repeat for more), q quits\r\n")
+	io.WriteString(w, b.String())
+}
+
+// containsIndex checks if the list contains the given index
+func containsIndex(list []int, index int) bool {
+	for _, i := range list {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jojomi/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderLarge(t *testing.T) {
+	s := sudoku.New(4)
+	for i := 0; i < 16; i++ {
+		s.Fields[i].Value = i + 1
+	}
+	p, err := newPlayer(s, "")
+	assert.Nil(t, err)
+	assert.Equal(t, sudoku.HexOneAlphabet, p.alphabet)
+
+	var b bytes.Buffer
+	p.cursor = 255
+	p.render(&b)
+	assert.Contains(t, b.String(), "|1234|5678|9ABC|DEFG|")
+
+	p.cursor = 16
+	p.handleKey('g')
+	assert.Equal(t, 16, p.s.Fields[16].Value)
+}
+
+func TestNewPlayerAlphabet(t *testing.T) {
+	_, err := newPlayer(sudoku.New(4), sudoku.DigitAlphabet)
+	assert.NotNil(t, err)
+
+	p, err := newPlayer(sudoku.New(3), "")
+	assert.Nil(t, err)
+	assert.Equal(t, sudoku.DigitAlphabet, p.alphabet)
+}
+
+func TestHintEliminationsReset(t *testing.T) {
+	s, _ := sudoku.FromFile("../testfiles/easy.sudoku")
+	p, _ := newPlayer(s, "")
+	// an empty field in row 0
+	entered := 2
+	p.cursor = entered
+	p.handleKey('3')
+	assert.Equal(t, 3, s.Fields[entered].Value)
+
+	for i := 0; i < 3; i++ {
+		p.handleKey('?')
+	}
+	assert.NotEmpty(t, p.eliminations)
+
+	p.cursor = 3*9 + 1
+	p.handleKey('8')
+	assert.NotEmpty(t, p.eliminations, "added values keep eliminations")
+
+	p.cursor = entered
+	p.handleKey(' ')
+	assert.Empty(t, p.eliminations)
+	assert.Equal(t, 0, s.Fields[entered].Value)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jojomi/sudoku/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddress      string
+	serveTimeout      time.Duration
+	serveMaxBodyBytes int64
+	serveMaxSize      int
+)
+
+// newServeCmd returns the serve command
+func newServeCmd() *cobra.Command {
+	var serveCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "serve an HTTP/JSON API to solve, validate, grade, hint and generate sudokus",
+		Args:  cobra.NoArgs,
+		Run:   cmdServe,
+	}
+	serveCmd.Flags().StringVar(&serveAddress, "address", "localhost:8080", "address to listen on")
+	serveCmd.Flags().DurationVar(&serveTimeout, "timeout", 10*time.Second, "maximum time spent per request")
+	serveCmd.Flags().Int64Var(&serveMaxBodyBytes, "max-body", 1<<20, "maximum size of request bodies in bytes")
+	serveCmd.Flags().IntVar(&serveMaxSize, "max-size", 5, "biggest box size accepted (5 for a 25x25 grid)")
+	return serveCmd
+}
+
+func cmdServe(cmd *cobra.Command, args []string) {
+	handler := server.New(server.Options{
+		Timeout:      serveTimeout,
+		MaxBodyBytes: serveMaxBodyBytes,
+		MaxSize:      serveMaxSize,
+	})
+	log.Printf("listening on http://%s, API description at /openapi.json", serveAddress)
+	log.Fatal(http.ListenAndServe(serveAddress, handler))
+}
@@ -0,0 +1,48 @@
+package sudoku
+
+import (
+	"strconv"
+	"strings"
+)
+
+// combinations calls fn for every sorted combination of k out of n indices
+// until fn returns false
+func combinations(n, k int, fn func([]int) bool) {
+	if k > n || k < 1 {
+		return
+	}
+	combination := make([]int, k)
+	var step func(pos, start int) bool
+	step = func(pos, start int) bool {
+		if pos == k {
+			return fn(combination)
+		}
+		for i := start; i <= n-(k-pos); i++ {
+			combination[pos] = i
+			if !step(pos+1, i+1) {
+				return false
+			}
+		}
+		return true
+	}
+	step(0, 0)
+}
+
+// containsInt checks if the slice contains the given value
+func containsInt(list []int, value int) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// formatInts returns a comma separated list of the given values
+func formatInts(list []int) string {
+	parts := make([]string, len(list))
+	for i, v := range list {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ", ")
+}
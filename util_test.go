@@ -0,0 +1,33 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombinations(t *testing.T) {
+	result := make([][]int, 0)
+	combinations(4, 2, func(c []int) bool {
+		result = append(result, append([]int{}, c...))
+		return true
+	})
+	assert.Equal(t, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}, result)
+
+	count := 0
+	combinations(4, 2, func(c []int) bool {
+		count++
+		return count < 3
+	})
+	assert.Equal(t, 3, count)
+
+	combinations(2, 3, func(c []int) bool {
+		t.Fail()
+		return true
+	})
+}
+
+func TestFormatInts(t *testing.T) {
+	assert.Equal(t, "", formatInts([]int{}))
+	assert.Equal(t, "1, 20, 3", formatInts([]int{1, 20, 3}))
+}
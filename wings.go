@@ -0,0 +1,130 @@
+package sudoku
+
+import "fmt"
+
+// SolveXYWing finds a pivot field with possible values {x,y} seeing two
+// pincer fields with {x,z} and {y,z}. Whichever value the pivot gets, one
+// pincer is z, so z is denied for all fields seeing both pincers (one step!)
+func (s Sudoku) SolveXYWing() SolvingResult {
+	for _, pivot := range s.fieldsWithPossibleValues(2) {
+		pivotValues := pivot.PossibleValues()
+		x, y := pivotValues[0], pivotValues[1]
+		for _, pincerX := range s.Peers(pivot) {
+			valuesX := pincerX.PossibleValues()
+			if pincerX.IsSolved() || len(valuesX) != 2 || !containsInt(valuesX, x) || containsInt(valuesX, y) {
+				continue
+			}
+			z := otherValue(valuesX, x)
+			for _, pincerY := range s.Peers(pivot) {
+				valuesY := pincerY.PossibleValues()
+				if pincerY.IsSolved() || len(valuesY) != 2 || !containsInt(valuesY, y) || !containsInt(valuesY, z) {
+					continue
+				}
+				res := s.denyForAllSeeing(XYWing, z, pivot, []*Field{pincerX, pincerY}, []*Field{pincerX, pincerY})
+				if res.FoundNew {
+					return res
+				}
+			}
+		}
+	}
+	return SolvingResult{}
+}
+
+// SolveXYZWing finds a pivot field with possible values {x,y,z} seeing two
+// pincer fields with {x,z} and {y,z}. One of the three fields is z, so z is
+// denied for all fields seeing all of them (one step!)
+func (s Sudoku) SolveXYZWing() SolvingResult {
+	for _, pivot := range s.fieldsWithPossibleValues(3) {
+		pivotValues := pivot.PossibleValues()
+		for _, z := range pivotValues {
+			pincers := make([]*Field, 0)
+			for _, peer := range s.Peers(pivot) {
+				values := peer.PossibleValues()
+				if peer.IsSolved() || len(values) != 2 || !containsInt(values, z) || !containsInt(pivotValues, otherValue(values, z)) {
+					continue
+				}
+				pincers = append(pincers, peer)
+			}
+			for i, pincerA := range pincers {
+				for _, pincerB := range pincers[i+1:] {
+					if otherValue(pincerA.PossibleValues(), z) == otherValue(pincerB.PossibleValues(), z) {
+						continue
+					}
+					res := s.denyForAllSeeing(XYZWing, z, pivot, []*Field{pincerA, pincerB}, []*Field{pivot, pincerA, pincerB})
+					if res.FoundNew {
+						return res
+					}
+				}
+			}
+		}
+	}
+	return SolvingResult{}
+}
+
+// denyForAllSeeing denies val for every field seeing all of the seen fields
+func (s Sudoku) denyForAllSeeing(technique TechniqueName, val int, pivot *Field, pincers, seen []*Field) SolvingResult {
+	eliminations := make([]Elimination, 0)
+	for _, field := range s.Fields {
+		if field.IsSolved() || field.NonValues.Contains(val) || field == pivot || containsField(pincers, field) {
+			continue
+		}
+		seesAll := true
+		for _, other := range seen {
+			if !s.Sees(field, other) {
+				seesAll = false
+				break
+			}
+		}
+		if seesAll {
+			field.DenyValue(val)
+			eliminations = append(eliminations, Elimination{Field: field.Index, Value: val})
+		}
+	}
+	if len(eliminations) == 0 {
+		return SolvingResult{}
+	}
+
+	pincerIndices := fieldIndices(pincers)
+	return SolvingResult{
+		FoundNew: true,
+		Step: Step{
+			Technique:    technique,
+			Fields:       append([]int{pivot.Index}, pincerIndices...),
+			Eliminations: eliminations,
+			Pivots:       []int{pivot.Index},
+			Pincers:      pincerIndices,
+			Message: fmt.Sprintf("Found %s with pivot field %d and pincer fields %s: One of them is %d, so it is denied for the fields seeing all of them",
+				technique, pivot.Index, formatInts(pincerIndices), val),
+		},
+	}
+}
+
+// fieldsWithPossibleValues returns all unsolved fields with exactly n
+// possible values
+func (s Sudoku) fieldsWithPossibleValues(n int) []*Field {
+	result := make([]*Field, 0)
+	for _, f := range s.Fields {
+		if !f.IsSolved() && f.Candidates().Len() == n {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// otherValue returns the value of a pair that is not the given one
+func otherValue(pair []int, value int) int {
+	if pair[0] == value {
+		return pair[1]
+	}
+	return pair[0]
+}
+
+// containsField checks if the slice contains the given field
+func containsField(fields []*Field, field *Field) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package sudoku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// allow leaves only the given values possible for a field
+func allow(f *Field, values ...int) {
+	for v := 1; v <= f.sudoku.MaxValue; v++ {
+		if !containsInt(values, v) {
+			f.DenyValue(v)
+		}
+	}
+}
+
+func TestSolveXYWing(t *testing.T) {
+	s := New(3)
+	// pivot at row 0 col 0, pincers at row 0 col 4 and row 4 col 0
+	allow(s.Fields[0], 1, 2)
+	allow(s.Fields[4], 1, 3)
+	allow(s.Fields[36], 2, 3)
+
+	res := s.SolveXYWing()
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, XYWing, res.Step.Technique)
+	assert.Equal(t, []int{0}, res.Step.Pivots)
+	assert.Equal(t, []int{4, 36}, res.Step.Pincers)
+	assert.Equal(t, []Elimination{{Field: 40, Value: 3}}, res.Step.Eliminations)
+
+	res = s.SolveXYWing()
+	assert.False(t, res.FoundNew)
+}
+
+func TestSolveXYZWing(t *testing.T) {
+	s := New(3)
+	// pivot at row 0 col 0, pincers at row 0 col 4 and row 1 col 1
+	allow(s.Fields[0], 1, 2, 3)
+	allow(s.Fields[4], 1, 3)
+	allow(s.Fields[10], 2, 3)
+
+	res := s.SolveXYZWing()
+	assert.True(t, res.FoundNew)
+	assert.Equal(t, XYZWing, res.Step.Technique)
+	assert.Equal(t, []int{0}, res.Step.Pivots)
+	assert.Equal(t, []int{4, 10}, res.Step.Pincers)
+	// fields of row 0 inside block 0 see all three
+	assert.Equal(t, []Elimination{{Field: 1, Value: 3}, {Field: 2, Value: 3}}, res.Step.Eliminations)
+}
+
+func TestSees(t *testing.T) {
+	s := New(3)
+	assert.True(t, s.Sees(s.Fields[0], s.Fields[8]))
+	assert.True(t, s.Sees(s.Fields[0], s.Fields[72]))
+	assert.True(t, s.Sees(s.Fields[0], s.Fields[20]))
+	assert.False(t, s.Sees(s.Fields[0], s.Fields[0]))
+	assert.False(t, s.Sees(s.Fields[0], s.Fields[30]))
+	assert.Len(t, s.Peers(s.Fields[40]), 20)
+}